@@ -37,7 +37,7 @@ func TestActivityService_ListStargazers(t *testing.T) {
 	}
 
 	want := []*Stargazer{{StarredAt: &Timestamp{time.Date(2002, time.February, 10, 15, 30, 0, 0, time.UTC)}, User: &User{ID: Int64(1)}}}
-	if !cmp.Equal(stargazers, want) {
+	if !cmp.Equal(stargazers, want, cmpUsers) {
 		t.Errorf("Activity.ListStargazers returned %+v, want %+v", stargazers, want)
 	}
 
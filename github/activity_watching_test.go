@@ -35,7 +35,7 @@ func TestActivityService_ListWatchers(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(watchers, want) {
+	if !cmp.Equal(watchers, want, cmpUsers) {
 		t.Errorf("Activity.ListWatchers returned %+v, want %+v", watchers, want)
 	}
 
@@ -40,7 +40,7 @@ func TestAdminUsers_Create(t *testing.T) {
 	}
 
 	want := &User{ID: Int64(1), Login: String("github")}
-	if !cmp.Equal(org, want) {
+	if !cmp.Equal(org, want, cmpUsers) {
 		t.Errorf("Admin.CreateUser returned %+v, want %+v", org, want)
 	}
 
@@ -118,7 +118,7 @@ func TestAppsService_AddRepository(t *testing.T) {
 	}
 
 	want := &Repository{ID: Int64(1), Name: String("n"), Description: String("d"), Owner: &User{Login: String("l")}, License: &License{Key: String("mit")}}
-	if !cmp.Equal(repo, want) {
+	if !cmp.Equal(repo, want, cmpUsers) {
 		t.Errorf("AddRepository returned %+v, want %+v", repo, want)
 	}
 
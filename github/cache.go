@@ -0,0 +1,194 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+)
+
+// headerVariedPrefix prefixes the headers recording, in a cached response,
+// the request header values that the response varies on.
+const headerVariedPrefix = "X-Varied-"
+
+// Cache stores serialized HTTP responses for a CachingTransport.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, and whether there was one.
+	Get(key string) ([]byte, bool)
+	// Set stores value for key.
+	Set(key string, value []byte)
+}
+
+// MemoryCache is a Cache that keeps responses in memory. The zero value is
+// ready to use.
+type MemoryCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+// Get returns the value stored for key, and whether there was one.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.items[key]
+	return value, ok
+}
+
+// Set stores value for key.
+func (c *MemoryCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		c.items = make(map[string][]byte)
+	}
+	c.items[key] = value
+}
+
+// CachingTransport is an http.RoundTripper that caches responses to GET
+// requests that carry an ETag or Last-Modified header, and revalidates them
+// with conditional requests. When the server responds with 304 Not Modified,
+// the cached response is returned instead, updated with the headers of the
+// 304 and with the X-From-Cache header set, which sets Response.FromCache.
+// Conditional requests answered with 304 do not count against the rate limit.
+//
+// Responses are cached by URL, and are only reused for requests that match
+// the original request on the headers listed in the response's Vary header,
+// such as Accept and Authorization.
+type CachingTransport struct {
+	// Transport is the underlying transport used to make requests.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Cache stores the responses. If nil, they are kept in memory, in a
+	// MemoryCache private to the transport.
+	Cache Cache
+
+	memory MemoryCache // Used when Cache is nil.
+}
+
+// cache returns the Cache to use.
+func (t *CachingTransport) cache() Cache {
+	if t.Cache == nil {
+		return &t.memory
+	}
+	return t.Cache
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet {
+		return transport.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached := t.lookup(key, req)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		// Update the cached response with the headers of the 304, such as
+		// a new ETag or rate limit, as RFC 7234, section 4.3.4 requires.
+		for header, values := range resp.Header {
+			if header != "Content-Length" {
+				cached.Header[header] = values
+			}
+		}
+		t.store(key, req, cached)
+		cached.Header.Set("X-From-Cache", "1")
+		return cached, nil
+	}
+
+	if resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "") {
+		t.store(key, req, resp)
+	}
+	return resp, nil
+}
+
+// lookup returns the response cached for key, if there is one and it may be
+// used for req.
+func (t *CachingTransport) lookup(key string, req *http.Request) *http.Response {
+	value, ok := t.cache().Get(key)
+	if !ok {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(value)), req)
+	if err != nil {
+		return nil
+	}
+	for _, header := range varyHeaders(resp) {
+		if resp.Header.Get(headerVariedPrefix+header) != req.Header.Get(header) {
+			resp.Body.Close()
+			return nil
+		}
+	}
+	return resp
+}
+
+// store adds resp to the cache under key, recording the values of the req
+// headers that resp varies on. The body of resp is buffered in the process.
+func (t *CachingTransport) store(key string, req *http.Request, resp *http.Response) {
+	for _, header := range varyHeaders(resp) {
+		resp.Header.Set(headerVariedPrefix+header, req.Header.Get(header))
+	}
+	value, err := httputil.DumpResponse(resp, true)
+	for _, header := range varyHeaders(resp) {
+		resp.Header.Del(headerVariedPrefix + header)
+	}
+	if err != nil {
+		return
+	}
+	t.cache().Set(key, value)
+}
+
+// varyHeaders returns the canonical names of the headers listed in the Vary
+// header of resp.
+func varyHeaders(resp *http.Response) []string {
+	var headers []string
+	for _, vary := range resp.Header.Values("Vary") {
+		for _, header := range strings.Split(vary, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				headers = append(headers, http.CanonicalHeaderKey(header))
+			}
+		}
+	}
+	return headers
+}
+
+// NewCachingClient returns a copy of base whose requests go through a
+// CachingTransport backed by cache, layered on top of the transport of base.
+// If cache is nil, responses are kept in memory. base itself is left
+// untouched.
+func NewCachingClient(base *Client, cache Cache) *Client {
+	c := base.copy()
+	defer c.initialize()
+	c.client.Transport = &CachingTransport{
+		Transport: c.client.Transport,
+		Cache:     cache,
+	}
+	return c
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewCachingClient(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls, notModified int
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		w.Header().Set("Vary", "Accept, Authorization")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id":1,"login":"u"}`)
+	})
+
+	client := NewCachingClient(base, new(MemoryCache))
+	ctx := context.Background()
+	want := &User{ID: Int64(1), Login: String("u")}
+	for i := 0; i < 3; i++ {
+		user, resp, err := client.Users.Get(ctx, "u")
+		if err != nil {
+			t.Fatalf("Users.Get returned error: %v", err)
+		}
+		if !cmp.Equal(user, want, cmpUsers) {
+			t.Errorf("Users.Get returned %+v, want %+v", user, want)
+		}
+		if got, want := resp.FromCache, i > 0; got != want {
+			t.Errorf("request %v: Response.FromCache = %v, want %v", i, got, want)
+		}
+	}
+	if calls != 3 || notModified != 2 {
+		t.Errorf("server got %v requests, %v of them conditional; want 3 and 2", calls, notModified)
+	}
+
+	// The base client does not use the cache.
+	if _, resp, err := base.Users.Get(ctx, "u"); err != nil || resp.Header.Get("X-From-Cache") != "" {
+		t.Errorf("base Users.Get returned resp %+v, err %v; want an uncached response", resp, err)
+	}
+	if notModified != 2 {
+		t.Errorf("base client made a conditional request")
+	}
+}
+
+func TestCachingTransport_notModifiedHeaders(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	var etags []string
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		etag := r.Header.Get("If-None-Match")
+		etags = append(etags, etag)
+		if etag != "" {
+			// The resource is unchanged, but its ETag was rotated.
+			w.Header().Set("ETag", fmt.Sprintf(`"v%v"`, len(etags)))
+			w.Header().Set(headerRateRemaining, fmt.Sprint(60-len(etags)))
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set(headerRateRemaining, "59")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	client := NewCachingClient(base, nil)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, resp, err := client.Users.Get(ctx, "u")
+		if err != nil {
+			t.Fatalf("Users.Get returned error: %v", err)
+		}
+		if got, want := resp.Header.Get(headerRateRemaining), fmt.Sprint(59-i); got != want {
+			t.Errorf("request %v: %v = %v, want %v from the latest response", i, headerRateRemaining, got, want)
+		}
+	}
+	if want := []string{"", `"v1"`, `"v2"`}; !cmp.Equal(etags, want) {
+		t.Errorf("server got If-None-Match %q, want %q", etags, want)
+	}
+}
+
+func TestCachingTransport_vary(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	var conditional int
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Authorization")
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprintf(w, `{"login":%q}`, r.Header.Get("Authorization"))
+	})
+
+	cache := new(MemoryCache)
+	ctx := context.Background()
+	a := NewCachingClient(base, cache)
+	b := NewCachingClient(base, cache)
+	for _, test := range []struct {
+		client *Client
+		token  string
+	}{
+		{a, "a"},
+		{b, "b"},
+	} {
+		user, _, err := test.client.Users.Get(ctx, "u", WithToken(test.token))
+		if err != nil {
+			t.Fatalf("Users.Get returned error: %v", err)
+		}
+		if got, want := user.GetLogin(), "Bearer "+test.token; got != want {
+			t.Errorf("Users.Get with token %q returned login %q, want %q", test.token, got, want)
+		}
+	}
+	if conditional != 0 {
+		t.Errorf("server got %v conditional requests, want 0 as the Authorization header differs", conditional)
+	}
+}
+
+func TestCachingTransport_nonGET(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	cache := new(MemoryCache)
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testHeader(t, r, "If-None-Match", "")
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	client := NewCachingClient(base, cache)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Self.Edit(ctx, &User{Name: String("n")}); err != nil {
+			t.Fatalf("Self.Edit returned error: %v", err)
+		}
+	}
+	if len(cache.items) != 0 {
+		t.Errorf("cache has %v items, want 0", len(cache.items))
+	}
+}
+
+func TestNewCachingClient_nilCache(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id":1,"login":"u"}`)
+	})
+
+	for name, client := range map[string]*Client{
+		"NewCachingClient":      NewCachingClient(base, nil),
+		"zero CachingTransport": zeroTransportClient(base),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 0; i < 2; i++ {
+				_, resp, err := client.Users.Get(ctx, "u")
+				if err != nil {
+					t.Fatalf("Users.Get returned error: %v", err)
+				}
+				if got, want := resp.Header.Get("X-From-Cache") != "", i > 0; got != want {
+					t.Errorf("request %v: response from cache = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func zeroTransportClient(base *Client) *Client {
+	c := NewClient(&http.Client{Transport: &CachingTransport{}})
+	c.BaseURL = base.BaseURL
+	return c
+}
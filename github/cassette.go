@@ -0,0 +1,214 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// interaction is a request and its response, as stored in a cassette file by
+// RecordingTransport and served back by ReplayTransport.
+type interaction struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	BodyHash   string      `json:"body_sha256,omitempty"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// key identifies the request of the interaction.
+func (i *interaction) key() string {
+	return i.Method + " " + i.URL + " " + i.BodyHash
+}
+
+// cassetteURL returns u as recorded in a cassette, with its credentials
+// redacted by sanitizeURL. u itself is left untouched.
+func cassetteURL(u *url.URL) string {
+	redacted := *u
+	return sanitizeURL(&redacted).String()
+}
+
+// readRequestBody returns the body of req, leaving req with an unread copy of
+// it, along with its hex-encoded SHA-256 hash, or "" if it is empty.
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return "", nil
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RecordingTransport is an http.RoundTripper that records each request it
+// makes, along with the response, to a cassette file, which a ReplayTransport
+// can serve back, such as in tests that should not reach the network.
+//
+// Requests are identified by their method, URL and a hash of their body;
+// their headers, including any credentials, are not recorded, and neither is
+// the client_secret parameter of their URL, which is redacted. The cassette
+// is rewritten after each request, so it is complete even if the program
+// exits without further notice.
+type RecordingTransport struct {
+	// Transport is the underlying transport used to make requests.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Path is the path of the cassette file. It is created or truncated by
+	// the first request.
+	Path string
+
+	mu           sync.Mutex
+	interactions []*interaction
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	bodyHash, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, &interaction{
+		Method:     req.Method,
+		URL:        cassetteURL(req.URL),
+		BodyHash:   bodyHash,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(t.Path, data, 0o644); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReplayTransport is an http.RoundTripper that serves the responses recorded
+// by a RecordingTransport, without making any request. Requests matching the
+// same recorded request get its responses in the order they were recorded,
+// with the last one repeated once they are used up. Requests that were not
+// recorded fail.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions map[string][]*interaction
+}
+
+// NewReplayTransport returns a ReplayTransport serving the responses recorded
+// in the cassette file at path.
+func NewReplayTransport(path string) (*ReplayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var recorded []*interaction
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("reading cassette %v: %w", path, err)
+	}
+	t := &ReplayTransport{interactions: make(map[string][]*interaction)}
+	for _, i := range recorded {
+		t.interactions[i.key()] = append(t.interactions[i.key()], i)
+	}
+	return t, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bodyHash, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := (&interaction{Method: req.Method, URL: cassetteURL(req.URL), BodyHash: bodyHash}).key()
+
+	t.mu.Lock()
+	queue := t.interactions[key]
+	if len(queue) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %v %v", req.Method, sanitizeURL(req.URL))
+	}
+	i := queue[0]
+	if len(queue) > 1 {
+		t.interactions[key] = queue[1:]
+	}
+	t.mu.Unlock()
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.StatusCode, http.StatusText(i.StatusCode)),
+		StatusCode:    i.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        i.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       req,
+	}, nil
+}
+
+// NewRecordingClient returns a copy of base whose requests go through a
+// RecordingTransport writing to the cassette file at path, layered on top of
+// the transport of base. base itself is left untouched.
+func NewRecordingClient(base *Client, path string) *Client {
+	c := base.copy()
+	defer c.initialize()
+	c.client.Transport = &RecordingTransport{
+		Transport: c.client.Transport,
+		Path:      path,
+	}
+	return c
+}
+
+// NewReplayClient returns a copy of base whose requests are answered by a
+// ReplayTransport from the cassette file at path, instead of by the transport
+// of base. base itself is left untouched. Its BaseURL should match the one
+// used while recording.
+func NewReplayClient(base *Client, path string) (*Client, error) {
+	transport, err := NewReplayTransport(path)
+	if err != nil {
+		return nil, err
+	}
+	c := base.copy()
+	defer c.initialize()
+	c.client.Transport = transport
+	return c, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewRecordingClient_replay(t *testing.T) {
+	base, mux, _, teardown := setup()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id":1,"login":"u"}`)
+	})
+	mux.HandleFunc("/user/keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"key":"ssh-ed25519 AAAA","title":"k"}`+"\n")
+		fmt.Fprint(w, `{"id":2,"key":"ssh-ed25519 AAAA","title":"k"}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+	recorder := NewRecordingClient(base, path).WithAuthToken("secret")
+	if _, _, err := recorder.Users.Get(ctx, "u"); err != nil {
+		t.Fatalf("recording Users.Get returned error: %v", err)
+	}
+	key := &Key{Key: String("ssh-ed25519 AAAA"), Title: String("k")}
+	if _, _, err := recorder.Users.CreateKey(ctx, key); err != nil {
+		t.Fatalf("recording Users.CreateKey returned error: %v", err)
+	}
+
+	// Replay with the server gone.
+	teardown()
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	if strings.Contains(string(cassette), "secret") {
+		t.Errorf("cassette contains the token: %s", cassette)
+	}
+
+	player, err := NewReplayClient(base, path)
+	if err != nil {
+		t.Fatalf("NewReplayClient returned error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		user, resp, err := player.Users.Get(ctx, "u")
+		if err != nil {
+			t.Fatalf("replayed Users.Get returned error: %v", err)
+		}
+		if want := (&User{ID: Int64(1), Login: String("u")}); !cmp.Equal(user, want, cmpUsers) {
+			t.Errorf("replayed Users.Get returned %+v, want %+v", user, want)
+		}
+		if got := resp.Header.Get("ETag"); got != `"v1"` {
+			t.Errorf("replayed ETag = %q, want %q", got, `"v1"`)
+		}
+	}
+
+	created, _, err := player.Users.CreateKey(ctx, key)
+	if err != nil {
+		t.Fatalf("replayed Users.CreateKey returned error: %v", err)
+	}
+	if want := (&Key{ID: Int64(2), Key: String("ssh-ed25519 AAAA"), Title: String("k")}); !cmp.Equal(created, want) {
+		t.Errorf("replayed Users.CreateKey returned %+v, want %+v", created, want)
+	}
+
+	// Requests that were not recorded, including those differing only in
+	// their body, fail.
+	if _, _, err := player.Users.Get(ctx, "other"); err == nil {
+		t.Error("replayed Users.Get of an unrecorded user returned no error")
+	}
+	if _, _, err := player.Users.CreateKey(ctx, &Key{Key: String("ssh-ed25519 BBBB")}); err == nil {
+		t.Error("replayed Users.CreateKey with an unrecorded body returned no error")
+	}
+}
+
+func TestNewRecordingClient_redactsURL(t *testing.T) {
+	base, mux, _, teardown := setup()
+
+	mux.HandleFunc("/applications/id/token", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"client_secret": "secret"})
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+	get := func(c *Client) error {
+		req, err := c.NewRequest("GET", "applications/id/token?client_secret=secret", nil)
+		if err != nil {
+			return err
+		}
+		_, err = c.Do(ctx, req, nil)
+		return err
+	}
+	if err := get(NewRecordingClient(base, path)); err != nil {
+		t.Fatalf("recording request returned error: %v", err)
+	}
+	teardown()
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	if strings.Contains(string(cassette), "secret=secret") {
+		t.Errorf("cassette contains the client secret: %s", cassette)
+	}
+
+	player, err := NewReplayClient(base, path)
+	if err != nil {
+		t.Fatalf("NewReplayClient returned error: %v", err)
+	}
+	if err := get(player); err != nil {
+		t.Errorf("replayed request returned error: %v", err)
+	}
+}
+
+func TestNewReplayClient_errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewReplayClient(NewClient(nil), filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("NewReplayClient of a missing cassette returned no error")
+	}
+
+	path := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReplayClient(NewClient(nil), path); err == nil {
+		t.Error("NewReplayClient of a malformed cassette returned no error")
+	}
+}
@@ -1421,7 +1421,7 @@ func TestCodeScanningService_ListCodeQLDatabases(t *testing.T) {
 		},
 	}
 
-	if !cmp.Equal(databases, want) {
+	if !cmp.Equal(databases, want, cmpUsers) {
 		t.Errorf("CodeScanning.ListCodeQLDatabases returned %+v, want %+v", databases, want)
 	}
 
@@ -1516,7 +1516,7 @@ func TestCodeScanningService_GetCodeQLDatabase(t *testing.T) {
 		URL:         String("s"),
 	}
 
-	if !cmp.Equal(database, want) {
+	if !cmp.Equal(database, want, cmpUsers) {
 		t.Errorf("CodeScanning.GetCodeQLDatabase returned %+v, want %+v", database, want)
 	}
 
@@ -86,7 +86,7 @@ func TestCodespacesService_ListInRepo(t *testing.T) {
 			ID: Int64(2),
 		},
 	}}
-	if !cmp.Equal(codespaces, want) {
+	if !cmp.Equal(codespaces, want, cmpUsers) {
 		t.Errorf("Codespaces.ListInRepo returned %+v, want %+v", codespaces, want)
 	}
 
@@ -656,7 +656,7 @@ func TestGistsService_ListCommits(t *testing.T) {
 			Total:     Int(180),
 		}}}
 
-	if !cmp.Equal(gistCommits, want) {
+	if !cmp.Equal(gistCommits, want, cmpUsers) {
 		t.Errorf("Gists.ListCommits returned %+v, want %+v", gistCommits, want)
 	}
 
@@ -961,7 +961,7 @@ func TestGistsService_ListForks(t *testing.T) {
 		CreatedAt: &Timestamp{time.Date(2010, time.January, 1, 00, 00, 00, 0, time.UTC)},
 		UpdatedAt: &Timestamp{time.Date(2013, time.January, 1, 00, 00, 00, 0, time.UTC)}}}
 
-	if !cmp.Equal(gistForks, want) {
+	if !cmp.Equal(gistForks, want, cmpUsers) {
 		t.Errorf("Gists.ListForks returned %+v, want %+v", gistForks, want)
 	}
 
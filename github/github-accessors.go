@@ -4246,6 +4246,54 @@ func (c *ContentReferenceEvent) GetSender() *User {
 	return c.Sender
 }
 
+// GetTotalContributions returns the TotalContributions field if it's non-nil, zero value otherwise.
+func (c *ContributionCalendar) GetTotalContributions() int {
+	if c == nil || c.TotalContributions == nil {
+		return 0
+	}
+	return *c.TotalContributions
+}
+
+// GetColor returns the Color field if it's non-nil, zero value otherwise.
+func (c *ContributionDay) GetColor() string {
+	if c == nil || c.Color == nil {
+		return ""
+	}
+	return *c.Color
+}
+
+// GetContributionCount returns the ContributionCount field if it's non-nil, zero value otherwise.
+func (c *ContributionDay) GetContributionCount() int {
+	if c == nil || c.ContributionCount == nil {
+		return 0
+	}
+	return *c.ContributionCount
+}
+
+// GetDate returns the Date field if it's non-nil, zero value otherwise.
+func (c *ContributionDay) GetDate() string {
+	if c == nil || c.Date == nil {
+		return ""
+	}
+	return *c.Date
+}
+
+// GetWeekday returns the Weekday field if it's non-nil, zero value otherwise.
+func (c *ContributionDay) GetWeekday() int {
+	if c == nil || c.Weekday == nil {
+		return 0
+	}
+	return *c.Weekday
+}
+
+// GetFirstDay returns the FirstDay field if it's non-nil, zero value otherwise.
+func (c *ContributionWeek) GetFirstDay() string {
+	if c == nil || c.FirstDay == nil {
+		return ""
+	}
+	return *c.FirstDay
+}
+
 // GetAvatarURL returns the AvatarURL field if it's non-nil, zero value otherwise.
 func (c *Contributor) GetAvatarURL() string {
 	if c == nil || c.AvatarURL == nil {
@@ -4958,6 +5006,22 @@ func (c *CustomRepoRoles) GetName() string {
 	return *c.Name
 }
 
+// GetSince returns the Since field if it's non-nil, zero value otherwise.
+func (d *DateRangeOptions) GetSince() Timestamp {
+	if d == nil || d.Since == nil {
+		return Timestamp{}
+	}
+	return *d.Since
+}
+
+// GetUntil returns the Until field if it's non-nil, zero value otherwise.
+func (d *DateRangeOptions) GetUntil() Timestamp {
+	if d == nil || d.Until == nil {
+		return Timestamp{}
+	}
+	return *d.Until
+}
+
 // GetQuerySuite returns the QuerySuite field if it's non-nil, zero value otherwise.
 func (d *DefaultSetupConfiguration) GetQuerySuite() string {
 	if d == nil || d.QuerySuite == nil {
@@ -11662,6 +11726,14 @@ func (m *MostRecentInstance) GetState() string {
 	return *m.State
 }
 
+// GetErrors returns the Errors map if it's non-nil, an empty map otherwise.
+func (m *MultiError) GetErrors() map[string]error {
+	if m == nil || m.Errors == nil {
+		return map[string]error{}
+	}
+	return m.Errors
+}
+
 // GetBase returns the Base field if it's non-nil, zero value otherwise.
 func (n *NewPullRequest) GetBase() string {
 	if n == nil || n.Base == nil {
@@ -21230,6 +21302,22 @@ func (s *SignatureVerification) GetVerified() bool {
 	return *s.Verified
 }
 
+// GetProvider returns the Provider field if it's non-nil, zero value otherwise.
+func (s *SocialAccount) GetProvider() string {
+	if s == nil || s.Provider == nil {
+		return ""
+	}
+	return *s.Provider
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (s *SocialAccount) GetURL() string {
+	if s == nil || s.URL == nil {
+		return ""
+	}
+	return *s.URL
+}
+
 // GetActor returns the Actor field.
 func (s *Source) GetActor() *User {
 	if s == nil {
@@ -23286,6 +23374,14 @@ func (u *User) GetSiteAdmin() bool {
 	return *u.SiteAdmin
 }
 
+// GetSpammy returns the Spammy field if it's non-nil, zero value otherwise.
+func (u *User) GetSpammy() bool {
+	if u == nil || u.Spammy == nil {
+		return false
+	}
+	return *u.Spammy
+}
+
 // GetStarredURL returns the StarredURL field if it's non-nil, zero value otherwise.
 func (u *User) GetStarredURL() string {
 	if u == nil || u.StarredURL == nil {
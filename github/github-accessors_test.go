@@ -4996,6 +4996,66 @@ func TestContentReferenceEvent_GetSender(tt *testing.T) {
 	c.GetSender()
 }
 
+func TestContributionCalendar_GetTotalContributions(tt *testing.T) {
+	var zeroValue int
+	c := &ContributionCalendar{TotalContributions: &zeroValue}
+	c.GetTotalContributions()
+	c = &ContributionCalendar{}
+	c.GetTotalContributions()
+	c = nil
+	c.GetTotalContributions()
+}
+
+func TestContributionDay_GetColor(tt *testing.T) {
+	var zeroValue string
+	c := &ContributionDay{Color: &zeroValue}
+	c.GetColor()
+	c = &ContributionDay{}
+	c.GetColor()
+	c = nil
+	c.GetColor()
+}
+
+func TestContributionDay_GetContributionCount(tt *testing.T) {
+	var zeroValue int
+	c := &ContributionDay{ContributionCount: &zeroValue}
+	c.GetContributionCount()
+	c = &ContributionDay{}
+	c.GetContributionCount()
+	c = nil
+	c.GetContributionCount()
+}
+
+func TestContributionDay_GetDate(tt *testing.T) {
+	var zeroValue string
+	c := &ContributionDay{Date: &zeroValue}
+	c.GetDate()
+	c = &ContributionDay{}
+	c.GetDate()
+	c = nil
+	c.GetDate()
+}
+
+func TestContributionDay_GetWeekday(tt *testing.T) {
+	var zeroValue int
+	c := &ContributionDay{Weekday: &zeroValue}
+	c.GetWeekday()
+	c = &ContributionDay{}
+	c.GetWeekday()
+	c = nil
+	c.GetWeekday()
+}
+
+func TestContributionWeek_GetFirstDay(tt *testing.T) {
+	var zeroValue string
+	c := &ContributionWeek{FirstDay: &zeroValue}
+	c.GetFirstDay()
+	c = &ContributionWeek{}
+	c.GetFirstDay()
+	c = nil
+	c.GetFirstDay()
+}
+
 func TestContributor_GetAvatarURL(tt *testing.T) {
 	var zeroValue string
 	c := &Contributor{AvatarURL: &zeroValue}
@@ -5862,6 +5922,26 @@ func TestCustomRepoRoles_GetName(tt *testing.T) {
 	c.GetName()
 }
 
+func TestDateRangeOptions_GetSince(tt *testing.T) {
+	var zeroValue Timestamp
+	d := &DateRangeOptions{Since: &zeroValue}
+	d.GetSince()
+	d = &DateRangeOptions{}
+	d.GetSince()
+	d = nil
+	d.GetSince()
+}
+
+func TestDateRangeOptions_GetUntil(tt *testing.T) {
+	var zeroValue Timestamp
+	d := &DateRangeOptions{Until: &zeroValue}
+	d.GetUntil()
+	d = &DateRangeOptions{}
+	d.GetUntil()
+	d = nil
+	d.GetUntil()
+}
+
 func TestDefaultSetupConfiguration_GetQuerySuite(tt *testing.T) {
 	var zeroValue string
 	d := &DefaultSetupConfiguration{QuerySuite: &zeroValue}
@@ -13648,6 +13728,16 @@ func TestMostRecentInstance_GetState(tt *testing.T) {
 	m.GetState()
 }
 
+func TestMultiError_GetErrors(tt *testing.T) {
+	zeroValue := map[string]error{}
+	m := &MultiError{Errors: zeroValue}
+	m.GetErrors()
+	m = &MultiError{}
+	m.GetErrors()
+	m = nil
+	m.GetErrors()
+}
+
 func TestNewPullRequest_GetBase(tt *testing.T) {
 	var zeroValue string
 	n := &NewPullRequest{Base: &zeroValue}
@@ -24726,6 +24816,26 @@ func TestSignatureVerification_GetVerified(tt *testing.T) {
 	s.GetVerified()
 }
 
+func TestSocialAccount_GetProvider(tt *testing.T) {
+	var zeroValue string
+	s := &SocialAccount{Provider: &zeroValue}
+	s.GetProvider()
+	s = &SocialAccount{}
+	s.GetProvider()
+	s = nil
+	s.GetProvider()
+}
+
+func TestSocialAccount_GetURL(tt *testing.T) {
+	var zeroValue string
+	s := &SocialAccount{URL: &zeroValue}
+	s.GetURL()
+	s = &SocialAccount{}
+	s.GetURL()
+	s = nil
+	s.GetURL()
+}
+
 func TestSource_GetActor(tt *testing.T) {
 	s := &Source{}
 	s.GetActor()
@@ -27137,6 +27247,16 @@ func TestUser_GetSiteAdmin(tt *testing.T) {
 	u.GetSiteAdmin()
 }
 
+func TestUser_GetSpammy(tt *testing.T) {
+	var zeroValue bool
+	u := &User{Spammy: &zeroValue}
+	u.GetSpammy()
+	u = &User{}
+	u.GetSpammy()
+	u = nil
+	u.GetSpammy()
+}
+
 func TestUser_GetStarredURL(tt *testing.T) {
 	var zeroValue string
 	u := &User{StarredURL: &zeroValue}
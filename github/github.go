@@ -10,6 +10,7 @@ package github
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -42,6 +44,9 @@ const (
 	headerRetryAfter    = "Retry-After"
 
 	headerTokenExpiration = "GitHub-Authentication-Token-Expiration"
+	headerOAuthScopes     = "X-OAuth-Scopes"
+	headerAcceptedScopes  = "X-Accepted-OAuth-Scopes"
+	headerRequestID       = "X-GitHub-Request-Id"
 
 	mediaTypeV3                = "application/vnd.github.v3+json"
 	defaultMediaType           = "application/octet-stream"
@@ -165,12 +170,67 @@ type Client struct {
 	// Base URL for uploading files.
 	UploadURL *url.URL
 
-	// User agent used when communicating with the GitHub API.
+	// User agent used when communicating with the GitHub API. It defaults to
+	// "go-github/<Version>"; set it to the empty string to omit the header.
 	UserAgent string
 
+	// APIVersion is sent as the X-GitHub-Api-Version header of each request,
+	// pinning the REST API version the client was written against. NewClient
+	// sets it to "2022-11-28"; set it to the empty string to omit the header.
+	// WithVersion overrides it for an individual request.
+	APIVersion string
+
+	// DefaultPerPage, if positive, is sent as the per_page query parameter of
+	// GET requests to URLs under BaseURL that do not already set one, such as
+	// list calls made with ListOptions.PerPage left at zero. Endpoints that are
+	// not paginated ignore it. Absolute URLs elsewhere, such as those of
+	// avatars and downloads, are left untouched.
+	DefaultPerPage int
+
+	// Logger, if non-nil, is called after each request sent to the GitHub API,
+	// including requests that fail. resp is nil if no response was received.
+	// The response body handed to Logger is a copy of at most its first 64
+	// KiB, so reading it does not affect decoding of the response, and large
+	// downloads are not buffered in memory.
+	Logger func(req *http.Request, resp *http.Response, err error)
+
+	// OnRequestComplete, if non-nil, is called after each request sent to the
+	// GitHub API with the request method and URL path, the response status
+	// code and the time taken to receive the response headers. It is useful
+	// for collecting metrics. status is 0 if no response was received.
+	OnRequestComplete func(method, path string, status int, latency time.Duration)
+
+	// MaxBodySize, if positive, is the maximum number of bytes read from a
+	// response body. Reading beyond it fails with a *ResponseTooLargeError.
+	// Zero means no limit.
+	MaxBodySize int64
+
+	// DisableCompression, if true, asks the API for uncompressed responses.
+	// Otherwise requests sent by the client, such as with Do, ask for
+	// gzip-compressed responses, which the client decompresses whatever the
+	// underlying transport.
+	DisableCompression bool
+
+	// DryRun, if true, makes Do skip every request whose method is not GET
+	// or HEAD, such as those following a user or editing a profile. The
+	// skipped request is passed to Logger, if set, and Do returns a 204 No
+	// Content response for it without contacting the API. GraphQL queries,
+	// which are sent with POST but change nothing, are still sent; GraphQL
+	// mutations are skipped.
+	DryRun bool
+
 	rateMu                  sync.Mutex
 	rateLimits              [categories]Rate // Rate limits for the client as determined by the most recent API calls.
 	secondaryRateLimitReset time.Time        // Secondary rate limit reset for the client as determined by the most recent API calls.
+	rateLimitGate           bool             // Whether requests wait for the rate limit to reset, see WithRateLimitGate.
+	rateLimitGateThreshold  int              // Number of remaining requests at which requests start waiting.
+	tokenExpiration         Timestamp        // Token expiration as reported by the most recent API call, see LastTokenExpiration.
+
+	whoAmIMu   sync.Mutex
+	whoAmI     *User     // User cached by WhoAmI, or nil.
+	whoAmIResp *Response // Response that whoAmI was decoded from.
+
+	hovercards *hovercardCache // Cache of Users.GetHovercard results, see WithHovercardCache.
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
@@ -259,6 +319,42 @@ type ListCursorOptions struct {
 	Cursor string `url:"cursor,omitempty"`
 }
 
+// DateRangeOptions specifies the optional parameters to List methods that
+// support filtering results by date. Unset bounds are omitted.
+type DateRangeOptions struct {
+	// Since only includes results updated at or after this time.
+	Since *Timestamp `url:"since,omitempty"`
+
+	// Until only includes results updated before this time.
+	Until *Timestamp `url:"until,omitempty"`
+}
+
+// SortOptions specifies the optional parameters to List methods that support
+// sorting their results. List option types embed it alongside ListOptions.
+type SortOptions struct {
+	// Sort is the property to sort the results by. The accepted values
+	// depend on the endpoint.
+	Sort string `url:"sort,omitempty"`
+
+	// Direction is the direction to sort the results in. Possible values
+	// are: asc, desc. The default depends on Sort.
+	Direction string `url:"direction,omitempty"`
+}
+
+// validate reports an error if Direction is set to a value other than asc or
+// desc.
+func (o *SortOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+	switch o.Direction {
+	case "", "asc", "desc":
+		return nil
+	default:
+		return fmt.Errorf("SortOptions.Direction %q is invalid; want asc or desc", o.Direction)
+	}
+}
+
 // UploadOptions specifies the parameters to methods that support uploads.
 type UploadOptions struct {
 	Name      string `url:"name,omitempty"`
@@ -282,13 +378,40 @@ type RawOptions struct {
 	Type RawType
 }
 
+// AddOptions adds the parameters in opts as URL query parameters to s, the
+// way the methods of this package do. opts must be a struct, or a pointer to
+// one, whose fields may contain "url" tags as understood by
+// github.com/google/go-querystring. A nil opts, or a nil pointer, leaves s
+// unchanged.
+//
+// Unlike the methods of this package, AddOptions always checks opts first,
+// and fails rather than silently dropping or garbling a parameter if a field
+// has a type that cannot be encoded, such as a map, or a "url" tag with an
+// unknown option, such as a misspelled omitempty.
+func AddOptions(s string, opts interface{}) (string, error) {
+	v := reflect.ValueOf(opts)
+	if !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return s, nil
+	}
+	if err := validateOptions(v.Type()); err != nil {
+		return s, err
+	}
+	return addOptions(s, opts)
+}
+
 // addOptions adds the parameters in opts as URL query parameters to s. opts
-// must be a struct whose fields may contain "url" tags.
+// must be a struct whose fields may contain "url" tags. In builds with the
+// github_debug tag, opts is checked as by AddOptions.
 func addOptions(s string, opts interface{}) (string, error) {
 	v := reflect.ValueOf(opts)
-	if v.Kind() == reflect.Ptr && v.IsNil() {
+	if !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
 		return s, nil
 	}
+	if debugOptions {
+		if err := validateOptions(v.Type()); err != nil {
+			return s, err
+		}
+	}
 
 	u, err := url.Parse(s)
 	if err != nil {
@@ -304,18 +427,134 @@ func addOptions(s string, opts interface{}) (string, error) {
 	return u.String(), nil
 }
 
+// urlTagOptions are the options of a "url" struct tag understood by
+// github.com/google/go-querystring.
+var urlTagOptions = map[string]bool{
+	"omitempty": true,
+	"int":       true,
+	"comma":     true,
+	"space":     true,
+	"semicolon": true,
+	"brackets":  true,
+	"numbered":  true,
+	"unix":      true,
+	"unixmilli": true,
+	"unixnano":  true,
+}
+
+var (
+	queryEncoderType = reflect.TypeOf((*query.Encoder)(nil)).Elem()
+	timeType         = reflect.TypeOf(time.Time{})
+)
+
+// validateOptions returns an error if typ, the type of the opts argument of
+// addOptions, has an exported field that go-querystring cannot encode into a
+// query parameter, or a "url" tag with an unknown option.
+func validateOptions(typ reflect.Type) error {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("options must be a struct, not %v", typ)
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous { // unexported
+			continue
+		}
+		tag := sf.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if opts != "" {
+			for _, opt := range strings.Split(opts, ",") {
+				if !urlTagOptions[opt] {
+					return fmt.Errorf("%v.%v: unknown url tag option %q", typ, sf.Name, opt)
+				}
+			}
+		}
+
+		ft := sf.Type
+		if implementsQueryEncoder(ft) {
+			continue
+		}
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft == timeType:
+		case ft.Kind() == reflect.Struct:
+			// Embedded structs without a name are flattened, and other
+			// structs are encoded as nested parameters; either way, their
+			// fields follow the same rules.
+			if err := validateOptions(ft); err != nil {
+				return err
+			}
+		case ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array:
+			if elem := ft.Elem(); !isQueryScalar(elem) {
+				return fmt.Errorf("%v.%v: unsupported element type %v", typ, sf.Name, elem)
+			}
+		case !isQueryScalar(ft):
+			if sf.Anonymous && name == "" {
+				continue // unexported non-struct embedded fields are skipped
+			}
+			return fmt.Errorf("%v.%v: unsupported type %v", typ, sf.Name, sf.Type)
+		}
+	}
+	return nil
+}
+
+// implementsQueryEncoder reports whether typ, or a pointer to it, encodes
+// itself into query parameters.
+func implementsQueryEncoder(typ reflect.Type) bool {
+	return typ.Implements(queryEncoderType) || reflect.PtrTo(typ).Implements(queryEncoderType)
+}
+
+// isQueryScalar reports whether go-querystring encodes a value of type typ,
+// after following pointers, as a single query parameter value.
+func isQueryScalar(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == timeType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String, reflect.Interface,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
 // NewClient returns a new GitHub API client. If a nil httpClient is
-// provided, a new http.Client will be used. To use API methods which require
+// provided, a new http.Client will be used, which does not forward the
+// Authorization header on redirects to other hosts. To use API methods which require
 // authentication, either use Client.WithAuthToken or provide NewClient with
 // an http.Client that will perform the authentication for you (such as that
 // provided by the golang.org/x/oauth2 library).
+//
+// A non-nil httpClient is used as-is: its Transport, and thus any tracing or
+// retrying it does, handles every request, and its CheckRedirect, even if nil,
+// decides which headers follow a redirect. Helpers such as WithAuthToken
+// layer on top of it in a copy of the client, leaving the original intact.
 func NewClient(httpClient *http.Client) *Client {
-	c := &Client{client: httpClient}
+	c := &Client{client: httpClient, APIVersion: defaultAPIVersion}
 	c.initialize()
 	return c
 }
 
 // WithAuthToken returns a copy of the client configured to use the provided token for the Authorization header.
+// The original client is left untouched, and the copy shares its underlying transport,
+// so it is safe to derive several clients that differ only in token.
+// The token is not sent when a request is redirected to a different host, such as
+// the CDN serving avatars and archives, nor with the requests that methods such as
+// UsersService.GetAvatar send directly to such hosts. Requests that already carry an
+// Authorization header, such as those made with the WithToken option, keep it.
 func (c *Client) WithAuthToken(token string) *Client {
 	c2 := c.copy()
 	defer c2.initialize()
@@ -325,6 +564,9 @@ func (c *Client) WithAuthToken(token string) *Client {
 	}
 	c2.client.Transport = roundTripperFunc(
 		func(req *http.Request) (*http.Response, error) {
+			if omitCredentials(req) || req.Header.Get("Authorization") != "" {
+				return transport.RoundTrip(req)
+			}
 			req = req.Clone(req.Context())
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 			return transport.RoundTrip(req)
@@ -378,10 +620,58 @@ func (c *Client) WithEnterpriseURLs(baseURL, uploadURL string) (*Client, error)
 	return c2, nil
 }
 
+// WithBaseURL returns a copy of the client that sends API requests to
+// baseURL, such as a proxy in front of GitHub at https://proxy.internal/github/.
+// Unlike WithEnterpriseURLs, the path of baseURL is used as given, apart from
+// a trailing slash being added if missing, so that request paths are resolved
+// beneath it. baseURL must be an absolute http or https URL. The upload URL is
+// left unchanged.
+func (c *Client) WithBaseURL(baseURL string) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("base URL %q must use the http or https scheme", baseURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("base URL %q has no host", baseURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
+
+	c2 := c.copy()
+	defer c2.initialize()
+	c2.BaseURL = u
+	return c2, nil
+}
+
+// WithRateLimitGate returns a copy of the client that, once the number of
+// remaining requests in a rate limit category drops to threshold, blocks new
+// requests in that category until the rate limit resets, instead of sending
+// them or failing with a *RateLimitError. Waiting honors the request context.
+//
+// The remaining count is taken from the most recent response and decremented
+// as requests are sent, so that concurrent callers do not race past the limit.
+func (c *Client) WithRateLimitGate(threshold int) *Client {
+	c2 := c.copy()
+	defer c2.initialize()
+	if threshold < 0 {
+		threshold = 0
+	}
+	c2.rateLimitGate = true
+	c2.rateLimitGateThreshold = threshold
+	return c2
+}
+
 // initialize sets default values and initializes services.
 func (c *Client) initialize() {
 	if c.client == nil {
-		c.client = &http.Client{}
+		c.client = &http.Client{CheckRedirect: checkRedirect}
 	}
 	if c.BaseURL == nil {
 		c.BaseURL, _ = url.Parse(defaultBaseURL)
@@ -425,7 +715,42 @@ func (c *Client) initialize() {
 	c.SecurityAdvisories = (*SecurityAdvisoriesService)(&c.common)
 	c.Teams = (*TeamsService)(&c.common)
 	c.Users = (*UsersService)(&c.common)
-	c.Self = (*SelfService)(c.Self)
+	c.Self = &SelfService{service: service{client: c}}
+}
+
+// maxRedirects is the number of redirects checkRedirect follows, the same as
+// the default policy of http.Client.
+const maxRedirects = 10
+
+// checkRedirect is the redirect policy of the http.Client created by
+// NewClient when none is provided. Unlike the default policy, which forwards
+// the Authorization header to subdomains of the original host, it drops the
+// header whenever the redirect leaves the host of the original request.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if req.URL.Host != via[0].URL.Host {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// crossHostRedirect reports whether req was created by following a redirect
+// to a host other than that of the request which started the redirect chain.
+func crossHostRedirect(req *http.Request) bool {
+	first := req
+	for first.Response != nil && first.Response.Request != nil {
+		first = first.Response.Request
+	}
+	return first.URL.Host != req.URL.Host
+}
+
+// omitCredentials reports whether req must be sent without the client's
+// credentials, because it goes to a host other than the API's, either by
+// following a redirect or as a request made by getURL.
+func omitCredentials(req *http.Request) bool {
+	return crossHostRedirect(req) || req.Context().Value(withoutCredentials) != nil
 }
 
 // copy returns a copy of the current client. It must be initialized before use.
@@ -433,16 +758,29 @@ func (c *Client) copy() *Client {
 	c.clientMu.Lock()
 	// can't use *c here because that would copy mutexes by value.
 	clone := Client{
-		client:                  c.client,
 		UserAgent:               c.UserAgent,
+		APIVersion:              c.APIVersion,
+		DefaultPerPage:          c.DefaultPerPage,
+		Logger:                  c.Logger,
+		MaxBodySize:             c.MaxBodySize,
+		DisableCompression:      c.DisableCompression,
+		DryRun:                  c.DryRun,
+		OnRequestComplete:       c.OnRequestComplete,
 		BaseURL:                 c.BaseURL,
 		UploadURL:               c.UploadURL,
 		secondaryRateLimitReset: c.secondaryRateLimitReset,
+		rateLimitGate:           c.rateLimitGate,
+		rateLimitGateThreshold:  c.rateLimitGateThreshold,
+	}
+	if c.client != nil {
+		// copy the http.Client too, so that changes to its transport in the
+		// copy (e.g. by WithAuthToken) do not leak back into c.
+		httpClient := *c.client
+		clone.client = &httpClient
+	} else {
+		clone.client = &http.Client{CheckRedirect: checkRedirect}
 	}
 	c.clientMu.Unlock()
-	if clone.client == nil {
-		clone.client = &http.Client{}
-	}
 	c.rateMu.Lock()
 	copy(clone.rateLimits[:], c.rateLimits[:])
 	c.rateMu.Unlock()
@@ -481,21 +819,92 @@ func WithVersion(version string) RequestOption {
 	}
 }
 
+// WithMediaType overrides the Accept header for this individual request.
+// It is useful for endpoints that can return alternate representations, such
+// as "application/vnd.github.raw" for raw file contents. When the response is
+// not JSON, pass an io.Writer to Client.Do to receive the raw body.
+func WithMediaType(mediaType string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("Accept", mediaType)
+	}
+}
+
+// WithRawJSON makes Do collect, for this individual request, the fields of
+// the response that User does not model, which User.Raw then returns. It
+// applies to responses decoded into a *User or a *[]*User, such as that of
+// UsersService.Get. Collecting them costs a second pass over the response, so
+// it is off by default.
+func WithRawJSON() RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), collectRawJSON, true))
+	}
+}
+
+// WithHeader adds the given header to this individual request, for example
+// to attach a correlation ID for tracing. Values are added rather than set, so
+// passing several WithHeader options for the same key keeps every value.
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Add(key, value)
+	}
+}
+
+// WithToken authenticates this individual request with the provided token,
+// without changing the credentials used by the client for other requests.
+// This is useful for servers acting on behalf of several installations or
+// users through a single client. It takes precedence over the token of
+// Client.WithAuthToken, but custom transports which set the Authorization
+// header themselves may override it.
+func WithToken(token string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+}
+
+// WithIfModifiedSince sets the If-Modified-Since header for this individual
+// request, so that GitHub responds with 304 Not Modified if the resource has
+// not changed since t. A 304 response is returned by Client.Do as an
+// *ErrorResponse whose Response.StatusCode is http.StatusNotModified.
+func WithIfModifiedSince(t time.Time) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("If-Modified-Since", t.UTC().Format(http.TimeFormat))
+	}
+}
+
+// WithIfMatch sets the If-Match header for this individual request, so that
+// GitHub only applies a change if the resource still has the given ETag, as
+// read from the ETag header of a previous response. If the resource has
+// changed since, Client.Do returns a *PreconditionFailedError.
+func WithIfMatch(etag string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set("If-Match", etag)
+	}
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
-// Relative URLs should always be specified without a preceding slash. If
-// specified, the value pointed to by body is JSON encoded and included as the
-// request body.
+// Relative URLs should be specified without a preceding slash; a single
+// leading slash is ignored so that "/user" and "user" resolve to the same URL.
+// If specified, the value pointed to by body is JSON encoded and included as
+// the request body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}, opts ...RequestOption) (*http.Request, error) {
 	if !strings.HasSuffix(c.BaseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
 	}
 
-	u, err := c.BaseURL.Parse(urlStr)
+	u, err := resolveURL(c.BaseURL, urlStr)
 	if err != nil {
 		return nil, err
 	}
 
+	if method == http.MethodGet && c.DefaultPerPage > 0 && underBaseURL(c.BaseURL, u) {
+		q := u.Query()
+		if q.Get("per_page") == "" {
+			q.Set("per_page", strconv.Itoa(c.DefaultPerPage))
+			u.RawQuery = q.Encode()
+		}
+	}
+
 	var buf io.ReadWriter
 	if body != nil {
 		buf = &bytes.Buffer{}
@@ -519,7 +928,9 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}, opts ...Req
 	if c.UserAgent != "" {
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
-	req.Header.Set(headerAPIVersion, defaultAPIVersion)
+	if c.APIVersion != "" {
+		req.Header.Set(headerAPIVersion, c.APIVersion)
+	}
 
 	for _, opt := range opts {
 		opt(req)
@@ -528,6 +939,22 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}, opts ...Req
 	return req, nil
 }
 
+// underBaseURL reports whether u is an API URL below base, as opposed to an
+// absolute URL elsewhere, such as that of an avatar or a download.
+func underBaseURL(base, u *url.URL) bool {
+	return u.Scheme == base.Scheme && u.Host == base.Host && strings.HasPrefix(u.Path, base.Path)
+}
+
+// resolveURL resolves urlStr relative to base. A single leading slash in
+// urlStr is dropped first, so that paths such as "/user" keep the path prefix
+// of base (e.g. "/api/v3/" on GitHub Enterprise) instead of replacing it.
+func resolveURL(base *url.URL, urlStr string) (*url.URL, error) {
+	if strings.HasPrefix(urlStr, "/") && !strings.HasPrefix(urlStr, "//") {
+		urlStr = urlStr[1:]
+	}
+	return base.Parse(urlStr)
+}
+
 // NewFormRequest creates an API request. A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash.
@@ -537,7 +964,7 @@ func (c *Client) NewFormRequest(urlStr string, body io.Reader, opts ...RequestOp
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
 	}
 
-	u, err := c.BaseURL.Parse(urlStr)
+	u, err := resolveURL(c.BaseURL, urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -552,7 +979,9 @@ func (c *Client) NewFormRequest(urlStr string, body io.Reader, opts ...RequestOp
 	if c.UserAgent != "" {
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
-	req.Header.Set(headerAPIVersion, defaultAPIVersion)
+	if c.APIVersion != "" {
+		req.Header.Set(headerAPIVersion, c.APIVersion)
+	}
 
 	for _, opt := range opts {
 		opt(req)
@@ -568,7 +997,7 @@ func (c *Client) NewUploadRequest(urlStr string, reader io.Reader, size int64, m
 	if !strings.HasSuffix(c.UploadURL.Path, "/") {
 		return nil, fmt.Errorf("UploadURL must have a trailing slash, but %q does not", c.UploadURL)
 	}
-	u, err := c.UploadURL.Parse(urlStr)
+	u, err := resolveURL(c.UploadURL, urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -585,8 +1014,12 @@ func (c *Client) NewUploadRequest(urlStr string, reader io.Reader, size int64, m
 	}
 	req.Header.Set("Content-Type", mediaType)
 	req.Header.Set("Accept", mediaTypeV3)
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set(headerAPIVersion, defaultAPIVersion)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.APIVersion != "" {
+		req.Header.Set(headerAPIVersion, c.APIVersion)
+	}
 
 	for _, opt := range opts {
 		opt(req)
@@ -597,7 +1030,9 @@ func (c *Client) NewUploadRequest(urlStr string, reader io.Reader, size int64, m
 
 // Response is a GitHub API response. This wraps the standard http.Response
 // returned from GitHub and provides convenient access to things like
-// pagination links.
+// pagination links. Headers without a dedicated field can be read from the
+// Header field of the embedded http.Response, which Do populates for error
+// responses too.
 type Response struct {
 	*http.Response
 
@@ -642,6 +1077,30 @@ type Response struct {
 	// token's expiration date. Timestamp is 0001-01-01 when token doesn't expire.
 	// So it is valid for TokenExpiration.Equal(Timestamp{}) or TokenExpiration.Time.After(time.Now())
 	TokenExpiration Timestamp
+
+	// TokenScopes lists the OAuth scopes granted to the token used for the
+	// request, as reported by the X-OAuth-Scopes header. RequiredScopes lists
+	// the scopes the endpoint accepts, as reported by the X-Accepted-OAuth-Scopes
+	// header. Both are nil if the header is absent, such as for requests that
+	// are not authenticated with an OAuth token.
+	TokenScopes    []string
+	RequiredScopes []string
+
+	// RequestID is the ID GitHub assigned to the request, as reported by the
+	// X-GitHub-Request-Id header. Include it when contacting GitHub support.
+	RequestID string
+
+	// FinalURL is the URL the response was served from, if the request was
+	// permanently redirected there, with status 301 Moved Permanently or 308
+	// Permanent Redirect, such as when a user or repository was renamed.
+	// Callers may use it to update stored references. It is nil if the
+	// request was not redirected, or if a redirect was only temporary.
+	FinalURL *url.URL
+
+	// FromCache reports whether the response was served from a client-side
+	// cache, such as the one enabled by Client.WithHovercardCache or a
+	// CachingTransport, rather than by the API.
+	FromCache bool
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -651,9 +1110,32 @@ func newResponse(r *http.Response) *Response {
 	response.populatePageValues()
 	response.Rate = parseRate(r)
 	response.TokenExpiration = parseTokenExpiration(r)
+	response.TokenScopes = parseScopes(r, headerOAuthScopes)
+	response.RequiredScopes = parseScopes(r, headerAcceptedScopes)
+	response.RequestID = r.Header.Get(headerRequestID)
+	response.FinalURL = permanentRedirectURL(r)
+	// X-From-Cache is set by CachingTransport and https://github.com/gregjones/httpcache
+	response.FromCache = r.Header.Get("X-From-Cache") != ""
 	return response
 }
 
+// permanentRedirectURL returns the URL of the request that r answers, if
+// that request was reached through permanent redirects only, and nil
+// otherwise.
+func permanentRedirectURL(r *http.Response) *url.URL {
+	if r.Request == nil || r.Request.Response == nil {
+		return nil
+	}
+	for req := r.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		switch req.Response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+		default:
+			return nil
+		}
+	}
+	return r.Request.URL
+}
+
 // populatePageValues parses the HTTP Link response headers and populates the
 // various pagination link values in the Response.
 func (r *Response) populatePageValues() {
@@ -780,17 +1262,54 @@ func parseTokenExpiration(r *http.Response) Timestamp {
 	return Timestamp{} // 0001-01-01 00:00:00
 }
 
+// LastTokenExpiration returns when the token used by the client expires, as
+// reported by the GitHub-Authentication-Token-Expiration header of the most
+// recent response, so that tools can warn before a fine-grained personal
+// access token runs out. It returns nil if no request has been made yet, or
+// if the most recent response did not report an expiration, such as for a
+// token that does not expire. Responses served from a cache are ignored.
+func (c *Client) LastTokenExpiration() *time.Time {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	if c.tokenExpiration.IsZero() {
+		return nil
+	}
+	t := c.tokenExpiration.Time
+	return &t
+}
+
+// parseScopes parses a comma separated list of OAuth scopes from header.
+// Returns nil if the header is not defined, and an empty slice if it is
+// defined but empty.
+func parseScopes(r *http.Response, header string) []string {
+	values := r.Header.Values(header)
+	if len(values) == 0 {
+		return nil
+	}
+	scopes := []string{}
+	for _, scope := range strings.Split(values[0], ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
 type requestContext uint8
 
 const (
 	bypassRateLimitCheck requestContext = iota
+	withoutCredentials                  // Set by getURL on requests to other hosts, see omitCredentials.
+	collectRawJSON                      // Set by WithRawJSON.
+	readOnly                            // Set by GraphQL on queries, which DryRun lets through.
 )
 
 // BareDo sends an API request and lets you handle the api response. If an error
 // or API Error occurs, the error will contain more information. Otherwise you
 // are supposed to read and close the response's Body. If rate limit is exceeded
 // and reset time is in the future, BareDo returns *RateLimitError immediately
-// without making a network API call.
+// without making a network API call, unless the client was created with
+// WithRateLimitGate, in which case it waits for the reset instead.
 //
 // The provided ctx must be non-nil, if it is nil an error is returned. If it is
 // canceled or times out, ctx.Err() will be returned.
@@ -801,11 +1320,31 @@ func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, erro
 
 	req = withContext(ctx, req)
 
+	if c.DryRun && req.Method != http.MethodGet && req.Method != http.MethodHead && ctx.Value(readOnly) == nil {
+		resp := &http.Response{
+			Status:     "204 No Content",
+			StatusCode: http.StatusNoContent,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       http.NoBody,
+			Request:    req,
+		}
+		c.logRequest(req, resp, nil)
+		return newResponse(resp), nil
+	}
+
 	rateLimitCategory := category(req.Method, req.URL.Path)
 
 	if bypass := ctx.Value(bypassRateLimitCheck); bypass == nil {
-		// If we've hit rate limit, don't make further requests before Reset time.
-		if err := c.checkRateLimitBeforeDo(req, rateLimitCategory); err != nil {
+		if c.rateLimitGate {
+			// Wait for the rate limit to reset rather than failing early.
+			if err := c.waitForRateLimitGate(ctx, rateLimitCategory); err != nil {
+				return nil, err
+			}
+		} else if err := c.checkRateLimitBeforeDo(req, rateLimitCategory); err != nil {
+			// If we've hit rate limit, don't make further requests before Reset time.
 			return &Response{
 				Response: err.Response,
 				Rate:     err.Rate,
@@ -819,7 +1358,23 @@ func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, erro
 		}
 	}
 
+	c.setAcceptEncoding(req)
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	if c.OnRequestComplete != nil {
+		var status int
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.OnRequestComplete(req.Method, req.URL.Path, status, time.Since(start))
+	}
+	if resp != nil {
+		decompress(resp)
+	}
+	if resp != nil && c.MaxBodySize > 0 {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, limit: c.MaxBodySize, resp: resp}
+	}
+	c.logRequest(req, resp, err)
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -847,6 +1402,7 @@ func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, erro
 	if response.Header.Get("X-From-Cache") == "" {
 		c.rateMu.Lock()
 		c.rateLimits[rateLimitCategory] = response.Rate
+		c.tokenExpiration = response.TokenExpiration
 		c.rateMu.Unlock()
 	}
 
@@ -880,29 +1436,153 @@ func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, erro
 	return response, err
 }
 
+// limitedBody is a response body that fails with a *ResponseTooLargeError
+// once more than limit bytes have been read from it.
+type limitedBody struct {
+	io.ReadCloser       // the original body
+	limit         int64 // maximum number of bytes to read
+	read          int64 // number of bytes read so far
+	resp          *http.Response
+	err           error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if int64(len(p)) > b.limit-b.read+1 {
+		p = p[:b.limit-b.read+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if b.read += int64(n); b.read > b.limit {
+		b.err = &ResponseTooLargeError{Response: b.resp, Limit: b.limit}
+		return n - int(b.read-b.limit), b.err
+	}
+	return n, err
+}
+
+// setAcceptEncoding sets the Accept-Encoding header of req, unless it is
+// already set, asking for a gzip-compressed response unless
+// c.DisableCompression is set. It is called just before sending requests,
+// rather than by NewRequest, so that requests sent by other clients keep the
+// transparent decompression of net/http.
+func (c *Client) setAcceptEncoding(req *http.Request) {
+	if req.Header.Get("Accept-Encoding") != "" {
+		return
+	}
+	if c.DisableCompression {
+		req.Header.Set("Accept-Encoding", "identity")
+	} else {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+}
+
+// decompress replaces the body of a gzip-encoded resp with its decompressed
+// form. The transport leaves the body compressed when the request set
+// Accept-Encoding itself, as setAcceptEncoding does, so every path that
+// calls setAcceptEncoding must call decompress too.
+func decompress(resp *http.Response) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipBody is a response body that decompresses the gzip-encoded body it
+// wraps as it is read.
+type gzipBody struct {
+	body io.ReadCloser // the original body
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	// Create the gzip.Reader lazily, as it reads the gzip header and would
+	// fail on the empty bodies of responses such as 304 Not Modified.
+	if b.zr == nil && b.err == nil {
+		b.zr, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
+
+// maxLoggedBodySize is the number of bytes of a response body that
+// logRequest hands to Logger.
+const maxLoggedBodySize = 64 << 10
+
+// logRequest passes the outcome of req to c.Logger, if one is set. Up to
+// maxLoggedBodySize bytes of the body of resp are buffered so that the logger
+// receives its own copy of them, while the caller still reads the whole body,
+// streamed from where the buffered bytes end.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, err error) {
+	if c.Logger == nil {
+		return
+	}
+	if resp == nil {
+		c.Logger(req, nil, err)
+		return
+	}
+
+	prefix, readErr := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodySize))
+	if readErr != nil && err == nil {
+		err = readErr
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+
+	logged := *resp
+	logged.Body = io.NopCloser(bytes.NewReader(prefix))
+	c.Logger(req, &logged, err)
+}
+
 // Do sends an API request and returns the API response. The API response is
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response body will be written to v, without attempting to first
 // decode it. If v is nil, and no error hapens, the response is returned as is.
+// Responses with status 204 No Content or 205 Reset Content are never decoded,
+// leaving v untouched.
 // If rate limit is exceeded and reset time is in the future, Do returns
 // *RateLimitError immediately without making a network API call.
 //
 // The provided ctx must be non-nil, if it is nil an error is returned. If it
 // is canceled or times out, ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	rawJSON := req.Context().Value(collectRawJSON) != nil
 	resp, err := c.BareDo(ctx, req)
 	if err != nil {
 		return resp, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusResetContent {
+		// These statuses carry no body, so there is nothing to decode into v.
+		return resp, err
+	}
+
 	switch v := v.(type) {
 	case nil:
 	case io.Writer:
 		_, err = io.Copy(v, resp.Body)
 	default:
-		decErr := json.NewDecoder(resp.Body).Decode(v)
+		var decErr error
+		if rawJSON {
+			decErr = decodeWithRawJSON(resp.Body, v)
+		} else {
+			decErr = json.NewDecoder(resp.Body).Decode(v)
+		}
 		if decErr == io.EOF {
 			decErr = nil // ignore EOF errors caused by empty response body
 		}
@@ -913,6 +1593,66 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return resp, err
 }
 
+// decodeWithRawJSON JSON decodes r into v, like Do, and then collects the
+// unmodeled fields of the users in v, if v is a *User or a *[]*User.
+func decodeWithRawJSON(r io.Reader, v interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return io.EOF
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case *User:
+		return v.setRawJSON(data)
+	case *[]*User:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		for i, user := range *v {
+			if user != nil && i < len(items) {
+				if err := user.setRawJSON(items[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// do implements the request pattern shared by most service methods. It adds
+// opts, if non-nil, as URL query parameters to urlStr, creates a request with
+// the given method and body, sends it and JSON decodes the response into a
+// new T. Any reqOpts are applied to the request.
+func do[T any](ctx context.Context, c *Client, method, urlStr string, body, opts interface{}, reqOpts ...RequestOption) (*T, *Response, error) {
+	if opts != nil {
+		var err error
+		urlStr, err = addOptions(urlStr, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := c.NewRequest(method, urlStr, body, reqOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := new(T)
+	resp, err := c.Do(ctx, req, v)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return v, resp, nil
+}
+
 // checkRateLimitBeforeDo does not make any network calls, but uses existing knowledge from
 // current client state in order to quickly check if *RateLimitError can be immediately returned
 // from Client.Do, and if so, returns it so that Client.Do can skip making a network API call unnecessarily.
@@ -940,6 +1680,34 @@ func (c *Client) checkRateLimitBeforeDo(req *http.Request, rateLimitCategory rat
 	return nil
 }
 
+// waitForRateLimitGate blocks until a request in rateLimitCategory may be sent
+// without the remaining rate limit dropping below the threshold configured by
+// WithRateLimitGate, or until ctx is done. A request that may be sent is
+// deducted from the remaining count straight away.
+func (c *Client) waitForRateLimitGate(ctx context.Context, rateLimitCategory rateLimitCategory) error {
+	for {
+		c.rateMu.Lock()
+		rate := &c.rateLimits[rateLimitCategory]
+		wait := time.Until(rate.Reset.Time)
+		if rate.Reset.Time.IsZero() || wait <= 0 || rate.Remaining > c.rateLimitGateThreshold {
+			if rate.Remaining > 0 {
+				rate.Remaining--
+			}
+			c.rateMu.Unlock()
+			return nil
+		}
+		c.rateMu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
 // checkSecondaryRateLimitBeforeDo does not make any network calls, but uses existing knowledge from
 // current client state in order to quickly check if *AbuseRateLimitError can be immediately returned
 // from Client.Do, and if so, returns it so that Client.Do can skip making a network API call unnecessarily.
@@ -1008,10 +1776,16 @@ type ErrorBlock struct {
 	CreatedAt *Timestamp `json:"created_at,omitempty"`
 }
 
+// Error formats the error, ending with the GitHub request ID, if any, so that
+// it can be quoted to GitHub support.
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v %+v",
+	msg := fmt.Sprintf("%v %v: %d %v %+v",
 		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
 		r.Response.StatusCode, r.Message, r.Errors)
+	if id := r.Response.Header.Get(headerRequestID); id != "" {
+		msg += fmt.Sprintf(" (request ID %v)", id)
+	}
+	return msg
 }
 
 // Is returns whether the provided error equals this error.
@@ -1058,6 +1832,22 @@ func (r *ErrorResponse) Is(target error) bool {
 	return true
 }
 
+// FieldErrors returns the codes of the validation errors in r.Errors, keyed
+// by the field they occurred on. Errors that do not name a field are
+// omitted, and only the first code is kept for a field with several errors.
+func (r *ErrorResponse) FieldErrors() map[string]string {
+	fields := make(map[string]string)
+	for _, e := range r.Errors {
+		if e.Field == "" {
+			continue
+		}
+		if _, ok := fields[e.Field]; !ok {
+			fields[e.Field] = e.Code
+		}
+	}
+	return fields
+}
+
 // TwoFactorAuthError occurs when using HTTP Basic Authentication for a user
 // that has two-factor authentication enabled. The request can be reattempted
 // by providing a one-time password in the request.
@@ -1065,6 +1855,75 @@ type TwoFactorAuthError ErrorResponse
 
 func (r *TwoFactorAuthError) Error() string { return (*ErrorResponse)(r).Error() }
 
+// Method returns how the one-time password is delivered to the user, as
+// reported by the X-GitHub-OTP response header: "app" for an authenticator
+// application or "sms" for a text message. It returns the empty string if
+// the method is unknown.
+func (r *TwoFactorAuthError) Method() string {
+	if r.Response == nil {
+		return ""
+	}
+	_, method, _ := strings.Cut(r.Response.Header.Get(headerOTP), ";")
+	return strings.TrimSpace(method)
+}
+
+// UnavailableForLegalReasonsError occurs when GitHub returns 451 Unavailable For
+// Legal Reasons, such as for content taken down in response to a DMCA notice.
+// The Block and DocumentationURL fields describe why access was blocked.
+type UnavailableForLegalReasonsError ErrorResponse
+
+func (r *UnavailableForLegalReasonsError) Error() string { return (*ErrorResponse)(r).Error() }
+
+// PreconditionFailedError occurs when GitHub returns 412 Precondition Failed,
+// such as when a request made with WithIfMatch targets a resource that has
+// changed since its ETag was read.
+type PreconditionFailedError ErrorResponse
+
+func (r *PreconditionFailedError) Error() string { return (*ErrorResponse)(r).Error() }
+
+// MultiError collects the errors of an operation made of several requests,
+// such as UsersService.GetMany, keyed by the item each error relates to.
+type MultiError struct {
+	Errors map[string]error
+}
+
+func (e *MultiError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	msgs := make([]string, len(keys))
+	for i, key := range keys {
+		msgs[i] = fmt.Sprintf("%v: %v", key, e.Errors[key])
+	}
+	return fmt.Sprintf("%d errors occurred: %v", len(keys), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the collected errors, so that errors.Is and errors.As can be
+// used to inspect them.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ResponseTooLargeError occurs when a response body is larger than
+// Client.MaxBodySize.
+type ResponseTooLargeError struct {
+	Response *http.Response // HTTP response that caused this error
+	Limit    int64          // maximum size of the response body, in bytes
+}
+
+func (r *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%v %v: %d response body exceeds %d bytes",
+		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
+		r.Response.StatusCode, r.Limit)
+}
+
 // RateLimitError occurs when GitHub returns 403 Forbidden response with a rate limit
 // remaining value of 0.
 type RateLimitError struct {
@@ -1116,7 +1975,8 @@ func (ae *AcceptedError) Is(target error) bool {
 }
 
 // AbuseRateLimitError occurs when GitHub returns 403 Forbidden response with the
-// "documentation_url" field value equal to "https://docs.github.com/en/rest/overview/resources-in-the-rest-api#secondary-rate-limits".
+// "documentation_url" field value equal to "https://docs.github.com/en/rest/overview/resources-in-the-rest-api#secondary-rate-limits",
+// or with a message stating that a secondary rate limit was exceeded.
 type AbuseRateLimitError struct {
 	Response *http.Response // HTTP response that caused this error
 	Message  string         `json:"message"` // error message
@@ -1209,6 +2069,8 @@ func (e *Error) UnmarshalJSON(data []byte) error {
 //
 // The error type will be *RateLimitError for rate limit exceeded errors,
 // *AcceptedError for 202 Accepted status codes,
+// *UnavailableForLegalReasonsError for 451 Unavailable For Legal Reasons,
+// *PreconditionFailedError for 412 Precondition Failed,
 // and *TwoFactorAuthError for two-factor authentication errors.
 func CheckResponse(r *http.Response) error {
 	if r.StatusCode == http.StatusAccepted {
@@ -1234,6 +2096,10 @@ func CheckResponse(r *http.Response) error {
 	switch {
 	case r.StatusCode == http.StatusUnauthorized && strings.HasPrefix(r.Header.Get(headerOTP), "required"):
 		return (*TwoFactorAuthError)(errorResponse)
+	case r.StatusCode == http.StatusUnavailableForLegalReasons:
+		return (*UnavailableForLegalReasonsError)(errorResponse)
+	case r.StatusCode == http.StatusPreconditionFailed:
+		return (*PreconditionFailedError)(errorResponse)
 	case r.StatusCode == http.StatusForbidden && r.Header.Get(headerRateRemaining) == "0":
 		return &RateLimitError{
 			Rate:     parseRate(r),
@@ -1242,7 +2108,8 @@ func CheckResponse(r *http.Response) error {
 		}
 	case r.StatusCode == http.StatusForbidden &&
 		(strings.HasSuffix(errorResponse.DocumentationURL, "#abuse-rate-limits") ||
-			strings.HasSuffix(errorResponse.DocumentationURL, "#secondary-rate-limits")):
+			strings.HasSuffix(errorResponse.DocumentationURL, "#secondary-rate-limits") ||
+			strings.Contains(errorResponse.Message, "exceeded a secondary rate limit")):
 		abuseRateLimitError := &AbuseRateLimitError{
 			Response: errorResponse.Response,
 			Message:  errorResponse.Message,
@@ -1558,6 +2425,35 @@ func formatRateReset(d time.Duration) string {
 	return fmt.Sprintf("[rate reset in %v]", timeString)
 }
 
+// getURL sends a GET request for the absolute URL u, such as that of an avatar
+// or of a signed archive download, through the client's http.Client, so that
+// its proxy, timeouts and transports apply. Unlike with NewRequest, no API
+// headers or default query parameters are added. If u is on a host other than
+// that of BaseURL, the request carries no credentials: WithAuthToken does not
+// add its token, and Authorization headers are dropped on redirects. It is the
+// responsibility of the caller to close the response body.
+func (c *Client) getURL(ctx context.Context, u, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL.Host != c.BaseURL.Host {
+		req = req.WithContext(context.WithValue(ctx, withoutCredentials, true))
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	client := *c.client
+	if client.CheckRedirect == nil {
+		client.CheckRedirect = checkRedirect
+	}
+	return client.Do(req)
+}
+
 // When using roundTripWithOptionalFollowRedirect, note that it
 // is the responsibility of the caller to close the response body.
 func (c *Client) roundTripWithOptionalFollowRedirect(ctx context.Context, u string, followRedirects bool, opts ...RequestOption) (*http.Response, error) {
@@ -1569,6 +2465,7 @@ func (c *Client) roundTripWithOptionalFollowRedirect(ctx context.Context, u stri
 	var resp *http.Response
 	// Use http.DefaultTransport if no custom Transport is configured
 	req = withContext(ctx, req)
+	c.setAcceptEncoding(req)
 	if c.client.Transport == nil {
 		resp, err = http.DefaultTransport.RoundTrip(req)
 	} else {
@@ -1577,6 +2474,7 @@ func (c *Client) roundTripWithOptionalFollowRedirect(ctx context.Context, u stri
 	if err != nil {
 		return nil, err
 	}
+	decompress(resp)
 
 	// If redirect response is returned, follow it
 	if followRedirects && resp.StatusCode == http.StatusMovedPermanently {
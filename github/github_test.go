@@ -6,6 +6,8 @@
 package github
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -19,6 +21,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +34,10 @@ const (
 	baseURLPath = "/api-v3"
 )
 
+// cmpUsers lets go-cmp compare values holding a User, whose raw JSON is kept
+// in an unexported field.
+var cmpUsers = cmp.AllowUnexported(User{})
+
 // setup sets up a test HTTP server along with a github.Client that is
 // configured to talk to that test server. Tests should register handlers on
 // mux which provide mock responses for the API method being tested.
@@ -169,6 +176,25 @@ func testJSONMarshal(t *testing.T, v interface{}, want string) {
 	}
 }
 
+// assertRoundTrip checks that marshaling v to JSON and unmarshaling the
+// result into a new value of the same type yields a value equal to v.
+func assertRoundTrip(t *testing.T, v interface{}) {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Unable to marshal JSON for %#v: %v", v, err)
+	}
+
+	got := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON %s: %v", b, err)
+	}
+
+	if diff := cmp.Diff(v, got, cmpUsers); diff != "" {
+		t.Errorf("JSON round trip of %T mismatch (-want +got):\n%v", v, diff)
+	}
+}
+
 // Test whether the v fields have the url tag and the parsing of v
 // produces query parameters that corresponds to the want string.
 func testAddURLOptions(t *testing.T, url string, v interface{}, want string) {
@@ -304,6 +330,48 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_customTransport(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "X-Trace", "1")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	var roundTrips int
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		roundTrips++
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Trace", "1")
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	httpClient := &http.Client{Transport: transport}
+	c := NewClient(httpClient)
+	c.BaseURL = client.BaseURL
+
+	if _, _, err := c.Users.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if roundTrips != 1 {
+		t.Errorf("custom transport called %v times, want 1", roundTrips)
+	}
+	if c.client != httpClient {
+		t.Error("NewClient did not use the provided http.Client")
+	}
+
+	// Layering auth on a copy keeps using, and does not replace, the transport.
+	if _, _, err := c.WithAuthToken("token").Users.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if roundTrips != 2 {
+		t.Errorf("custom transport called %v times, want 2", roundTrips)
+	}
+	if got := reflect.ValueOf(httpClient.Transport).Pointer(); got != reflect.ValueOf(transport).Pointer() {
+		t.Error("WithAuthToken replaced the transport of the provided http.Client")
+	}
+}
+
 func TestNewClientWithEnvProxy(t *testing.T) {
 	client := NewClientWithEnvProxy()
 	if got, want := client.BaseURL.String(), defaultBaseURL; got != want {
@@ -343,6 +411,123 @@ func TestWithAuthToken(t *testing.T) {
 	validate(NewTokenClient(context.Background(), token))
 }
 
+func TestWithAuthToken_existingHeader(t *testing.T) {
+	token := "gh_test_token"
+	var gotAuthHeaderVals []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeaderVals = r.Header["Authorization"]
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil).WithAuthToken(token)
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest returned unexpected error: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer per_request_token")
+	if _, err := c.Client().Do(req); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+
+	// The header already set is kept, and not duplicated.
+	want := []string{"Bearer per_request_token"}
+	if diff := cmp.Diff(want, gotAuthHeaderVals); diff != "" {
+		t.Errorf("Authorization header values mismatch (-want +got):\n%s", diff)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer per_request_token" {
+		t.Errorf("original request Authorization header was modified to %q", got)
+	}
+}
+
+func TestWithAuthToken_originalUnaffected(t *testing.T) {
+	var gotAuthHeaderVals [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeaderVals = append(gotAuthHeaderVals, r.Header["Authorization"])
+	}))
+	defer srv.Close()
+
+	base := NewClient(nil)
+	a := base.WithAuthToken("token_a")
+	b := base.WithAuthToken("token_b")
+	if a == base || b == base || a.Client() == base.Client() {
+		t.Fatal("WithAuthToken did not return a copy of the client")
+	}
+
+	for _, c := range []*Client{base, a, b, base} {
+		if _, err := c.Client().Get(srv.URL); err != nil {
+			t.Fatalf("Get returned unexpected error: %v", err)
+		}
+	}
+
+	want := [][]string{nil, {"Bearer token_a"}, {"Bearer token_b"}, nil}
+	if diff := cmp.Diff(want, gotAuthHeaderVals); diff != "" {
+		t.Errorf("Authorization header values mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithAuthToken_crossHostRedirect(t *testing.T) {
+	var gotAuthHeaderVals []string
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeaderVals = r.Header["Authorization"]
+	}))
+	defer cdn.Close()
+
+	// Serve the API on a different host than the CDN.
+	cdnURL, _ := url.Parse(cdn.URL)
+	cdnURL.Host = strings.Replace(cdnURL.Host, "127.0.0.1", "localhost", 1)
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/avatar", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Authorization", "Bearer token")
+		http.Redirect(w, r, cdnURL.String(), http.StatusFound)
+	})
+
+	for name, c := range map[string]*Client{
+		"WithAuthToken": client.WithAuthToken("token"),
+		"WithToken":     client,
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotAuthHeaderVals = []string{"unset"}
+			req, err := c.NewRequest("GET", "avatar", nil, WithToken("token"))
+			if err != nil {
+				t.Fatalf("NewRequest returned unexpected error: %v", err)
+			}
+			if _, err := c.Do(context.Background(), req, nil); err != nil {
+				t.Fatalf("Do returned unexpected error: %v", err)
+			}
+			if gotAuthHeaderVals != nil {
+				t.Errorf("Authorization header forwarded to %v: %v", cdnURL.Host, gotAuthHeaderVals)
+			}
+		})
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	via := []*http.Request{httptest.NewRequest("GET", "https://api.github.com/user", nil)}
+	for _, test := range []struct {
+		target   string
+		wantAuth string
+	}{
+		{"https://api.github.com/users/u", "Bearer token"},
+		{"https://avatars.githubusercontent.com/u/1", ""},
+		{"https://x.api.github.com/user", ""},
+	} {
+		req := httptest.NewRequest("GET", test.target, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		if err := checkRedirect(req, via); err != nil {
+			t.Fatalf("checkRedirect(%v) returned unexpected error: %v", test.target, err)
+		}
+		if got := req.Header.Get("Authorization"); got != test.wantAuth {
+			t.Errorf("checkRedirect(%v) left Authorization %q, want %q", test.target, got, test.wantAuth)
+		}
+	}
+
+	if err := checkRedirect(via[0], make([]*http.Request, maxRedirects)); err == nil {
+		t.Error("checkRedirect after too many redirects returned nil error")
+	}
+}
+
 func TestWithEnterpriseURLs(t *testing.T) {
 	for _, test := range []struct {
 		name          string
@@ -461,6 +646,76 @@ func TestWithEnterpriseURLs(t *testing.T) {
 	}
 }
 
+func TestWithEnterpriseURLs_NewRequest(t *testing.T) {
+	c, err := NewClient(nil).WithEnterpriseURLs("https://custom-url", "https://custom-upload-url")
+	if err != nil {
+		t.Fatalf("WithEnterpriseURLs returned unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		urlStr, want string
+	}{
+		{"users/foo", "https://custom-url/api/v3/users/foo"},
+		{"user", "https://custom-url/api/v3/user"},
+		{"https://other-url/api/v3/user", "https://other-url/api/v3/user"},
+	} {
+		req, err := c.NewRequest("GET", test.urlStr, nil)
+		if err != nil {
+			t.Fatalf("NewRequest(%q) returned unexpected error: %v", test.urlStr, err)
+		}
+		if got := req.URL.String(); got != test.want {
+			t.Errorf("NewRequest(%q) URL is %v, want %v", test.urlStr, got, test.want)
+		}
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	for _, test := range []struct {
+		baseURL string
+		want    string
+		wantErr string
+	}{
+		{baseURL: "https://proxy.internal/github/", want: "https://proxy.internal/github/"},
+		{baseURL: "https://proxy.internal/github", want: "https://proxy.internal/github/"},
+		{baseURL: "http://localhost:8080", want: "http://localhost:8080/"},
+		{baseURL: "ftp://proxy.internal/github/", wantErr: "http or https scheme"},
+		{baseURL: "proxy.internal/github/", wantErr: "http or https scheme"},
+		{baseURL: "https:///github/", wantErr: "no host"},
+		{baseURL: "bogus\nbase\nURL", wantErr: "invalid control character in URL"},
+	} {
+		t.Run(test.baseURL, func(t *testing.T) {
+			base := NewClient(nil)
+			c, err := base.WithBaseURL(test.baseURL)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("WithBaseURL returned error %v, want it to contain %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WithBaseURL returned unexpected error: %v", err)
+			}
+			if got := c.BaseURL.String(); got != test.want {
+				t.Errorf("BaseURL is %v, want %v", got, test.want)
+			}
+			if got, want := c.UploadURL.String(), base.UploadURL.String(); got != want {
+				t.Errorf("UploadURL is %v, want it unchanged as %v", got, want)
+			}
+			if got, want := base.BaseURL.String(), defaultBaseURL; got != want {
+				t.Errorf("original BaseURL is %v, want it unchanged as %v", got, want)
+			}
+
+			req, err := c.NewRequest("GET", "users/u", nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned unexpected error: %v", err)
+			}
+			if got, want := req.URL.String(), test.want+"users/u"; got != want {
+				t.Errorf("NewRequest URL is %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 // Ensure that length of Client.rateLimits is the same as number of fields in RateLimits struct.
 func TestClient_rateLimits(t *testing.T) {
 	if got, want := len(Client{}.rateLimits), reflect.TypeOf(RateLimits{}).NumField(); got != want {
@@ -526,6 +781,175 @@ func TestNewRequest(t *testing.T) {
 	}
 }
 
+func TestNewRequest_APIVersion(t *testing.T) {
+	c := NewClient(nil)
+	if got, want := c.APIVersion, defaultAPIVersion; got != want {
+		t.Errorf("NewClient APIVersion is %v, want %v", got, want)
+	}
+
+	c.APIVersion = "2026-03-10"
+	req, _ := c.NewRequest("GET", "users/u", nil)
+	if got, want := req.Header.Get(headerAPIVersion), "2026-03-10"; got != want {
+		t.Errorf("NewRequest() %v header is %v, want %v", headerAPIVersion, got, want)
+	}
+
+	// The empty string omits the header, also in copies of the client.
+	c.APIVersion = ""
+	for _, c := range []*Client{c, c.WithAuthToken("t")} {
+		req, _ = c.NewRequest("GET", "users/u", nil)
+		if got, ok := req.Header[headerAPIVersion]; ok {
+			t.Errorf("NewRequest() %v header is %v, want it omitted", headerAPIVersion, got)
+		}
+	}
+}
+
+func TestNewRequest_WithMediaType(t *testing.T) {
+	c := NewClient(nil)
+
+	req, _ := c.NewRequest("GET", "users/u", nil)
+	if got, want := req.Header.Get("Accept"), mediaTypeV3; got != want {
+		t.Errorf("NewRequest() Accept header is %v, want %v", got, want)
+	}
+
+	req, _ = c.NewRequest("GET", "users/u", nil, WithMediaType("application/vnd.github.raw"))
+	if got, want := req.Header.Get("Accept"), "application/vnd.github.raw"; got != want {
+		t.Errorf("NewRequest() Accept header is %v, want %v", got, want)
+	}
+}
+
+func TestNewRequest_DefaultPerPage(t *testing.T) {
+	c := NewClient(nil)
+	c.DefaultPerPage = 100
+
+	for _, test := range []struct {
+		method string
+		opts   *ListOptions
+		want   string
+	}{
+		{"GET", nil, defaultBaseURL + "users/u/followers?per_page=100"},
+		{"GET", &ListOptions{Page: 2}, defaultBaseURL + "users/u/followers?page=2&per_page=100"},
+		{"GET", &ListOptions{PerPage: 10}, defaultBaseURL + "users/u/followers?per_page=10"},
+		{"PUT", nil, defaultBaseURL + "users/u/followers"},
+	} {
+		u, err := addOptions("users/u/followers", test.opts)
+		if err != nil {
+			t.Fatalf("addOptions returned unexpected error: %v", err)
+		}
+		req, err := c.NewRequest(test.method, u, nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned unexpected error: %v", err)
+		}
+		if got := req.URL.String(); got != test.want {
+			t.Errorf("NewRequest(%v, %+v) URL is %v, want %v", test.method, test.opts, got, test.want)
+		}
+	}
+
+	// Absolute URLs outside of BaseURL are left alone.
+	for _, u := range []string{
+		"https://avatars.githubusercontent.com/u/1?v=4",
+		"https://uploads.github.com/repos/o/r/releases/1/assets",
+		"http://api.github.com/users/u/followers",
+	} {
+		req, err := c.NewRequest("GET", u, nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned unexpected error: %v", err)
+		}
+		if got := req.URL.String(); got != u {
+			t.Errorf("NewRequest(GET, %v) URL is %v, want it unchanged", u, got)
+		}
+	}
+}
+
+func TestNewRequest_WithHeader(t *testing.T) {
+	c := NewClient(nil)
+
+	req, err := c.NewRequest("GET", "users/u", nil,
+		WithHeader("X-Correlation-ID", "abc"),
+		WithHeader("X-Request-Source", "cli"),
+		WithHeader("X-Request-Source", "cron"),
+	)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+
+	if got, want := req.Header.Values("X-Correlation-ID"), []string{"abc"}; !cmp.Equal(got, want) {
+		t.Errorf("NewRequest() X-Correlation-ID header is %v, want %v", got, want)
+	}
+	if got, want := req.Header.Values("X-Request-Source"), []string{"cli", "cron"}; !cmp.Equal(got, want) {
+		t.Errorf("NewRequest() X-Request-Source header is %v, want %v", got, want)
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var gotAuthHeaderVals []string
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeaderVals = append(gotAuthHeaderVals, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	ctx := context.Background()
+	for _, token := range []string{"installation_a", "installation_b"} {
+		if _, _, err := client.Users.Get(ctx, "u", WithToken(token)); err != nil {
+			t.Fatalf("Users.Get returned unexpected error: %v", err)
+		}
+	}
+	if _, _, err := client.Users.Get(ctx, "u"); err != nil {
+		t.Fatalf("Users.Get returned unexpected error: %v", err)
+	}
+
+	want := []string{"Bearer installation_a", "Bearer installation_b", ""}
+	if diff := cmp.Diff(want, gotAuthHeaderVals); diff != "" {
+		t.Errorf("Authorization header values mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithToken_withAuthToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var gotAuthHeaderVals []string
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeaderVals = append(gotAuthHeaderVals, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	client = client.WithAuthToken("default")
+	ctx := context.Background()
+	if _, _, err := client.Users.Get(ctx, "u", WithToken("installation_a")); err != nil {
+		t.Fatalf("Users.Get returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Users.Get(ctx, "u"); err != nil {
+		t.Fatalf("Users.Get returned unexpected error: %v", err)
+	}
+
+	want := []string{"Bearer installation_a", "Bearer default"}
+	if diff := cmp.Diff(want, gotAuthHeaderVals); diff != "" {
+		t.Errorf("Authorization header values mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewRequest_bodyForAnyMethod(t *testing.T) {
+	c := NewClient(nil)
+
+	for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		req, err := c.NewRequest(method, "user/emails", []string{"a@example.com"})
+		if err != nil {
+			t.Fatalf("NewRequest(%v) returned unexpected error: %v", method, err)
+		}
+
+		if got, want := req.Header.Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("NewRequest(%v) Content-Type is %v, want %v", method, got, want)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if got, want := string(body), `["a@example.com"]`+"\n"; got != want {
+			t.Errorf("NewRequest(%v) Body is %q, want %q", method, got, want)
+		}
+	}
+}
+
 func TestNewRequest_invalidJSON(t *testing.T) {
 	c := NewClient(nil)
 
@@ -569,6 +993,39 @@ func TestNewRequest_emptyUserAgent(t *testing.T) {
 	}
 }
 
+func TestNewRequest_customUserAgent(t *testing.T) {
+	c := NewClient(nil)
+	c.UserAgent = "corp-proxy-approved/1.0"
+
+	req, err := c.NewRequest("GET", ".", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+	if got, want := req.Header.Get("User-Agent"), "corp-proxy-approved/1.0"; got != want {
+		t.Errorf("NewRequest() User-Agent is %v, want %v", got, want)
+	}
+
+	req, err = c.NewUploadRequest("https://example.com/", nil, 0, "")
+	if err != nil {
+		t.Fatalf("NewUploadRequest returned unexpected error: %v", err)
+	}
+	if got, want := req.Header.Get("User-Agent"), "corp-proxy-approved/1.0"; got != want {
+		t.Errorf("NewUploadRequest() User-Agent is %v, want %v", got, want)
+	}
+}
+
+func TestNewUploadRequest_emptyUserAgent(t *testing.T) {
+	c := NewClient(nil)
+	c.UserAgent = ""
+	req, err := c.NewUploadRequest("https://example.com/", nil, 0, "")
+	if err != nil {
+		t.Fatalf("NewUploadRequest returned unexpected error: %v", err)
+	}
+	if _, ok := req.Header["User-Agent"]; ok {
+		t.Fatal("constructed request contains unexpected User-Agent header")
+	}
+}
+
 // If a nil body is passed to github.NewRequest, make sure that nil is also
 // passed to http.NewRequest. In most cases, passing an io.Reader that returns
 // no content is fine, since there is no difference between an HTTP request
@@ -609,6 +1066,21 @@ func TestNewRequest_errorForNoTrailingSlash(t *testing.T) {
 	}
 }
 
+func TestNewRequest_leadingSlash(t *testing.T) {
+	c := NewClient(nil)
+	c.BaseURL, _ = url.Parse("https://custom-url/api/v3/")
+
+	for _, urlStr := range []string{"/users/foo", "users/foo"} {
+		req, err := c.NewRequest("GET", urlStr, nil)
+		if err != nil {
+			t.Fatalf("NewRequest(%q) returned unexpected error: %v", urlStr, err)
+		}
+		if got, want := req.URL.String(), "https://custom-url/api/v3/users/foo"; got != want {
+			t.Errorf("NewRequest(%q) URL is %v, want %v", urlStr, got, want)
+		}
+	}
+}
+
 func TestNewFormRequest(t *testing.T) {
 	c := NewClient(nil)
 
@@ -639,6 +1111,11 @@ func TestNewFormRequest(t *testing.T) {
 		t.Errorf("NewRequest() %v header is %v, want %v", headerAPIVersion, got, want)
 	}
 
+	// Accept-Encoding is left to the transport until the request is sent.
+	if got := req.Header.Get("Accept-Encoding"); got != "" {
+		t.Errorf("NewFormRequest() Accept-Encoding is %v, want it unset", got)
+	}
+
 	req, _ = c.NewFormRequest(inURL, inBody, WithVersion("2022-11-29"))
 	apiVersion = req.Header.Get(headerAPIVersion)
 	if got, want := apiVersion, "2022-11-29"; got != want {
@@ -910,6 +1387,41 @@ func TestResponse_beforeAfterPagination(t *testing.T) {
 	}
 }
 
+func TestDo_linkHeaderPagination(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://api.github.com/users?since=MDQ6VXNlcjQ2&per_page=2>; rel="next", `+
+			`<https://api.github.com/users?page=1&per_page=2>; rel="first", `+
+			`<https://api.github.com/users?page=3&per_page=2>; rel="prev", `+
+			`<https://api.github.com/users?page=9&per_page=2>; rel="last"`)
+		fmt.Fprint(w, `[]`)
+	})
+
+	req, _ := client.NewRequest("GET", "users", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+
+	if got, want := resp.FirstPage, 1; got != want {
+		t.Errorf("resp.FirstPage: %v, want %v", got, want)
+	}
+	if got, want := resp.PrevPage, 3; got != want {
+		t.Errorf("resp.PrevPage: %v, want %v", got, want)
+	}
+	if got, want := resp.NextPage, 0; got != want {
+		t.Errorf("resp.NextPage: %v, want %v", got, want)
+	}
+	if got, want := resp.LastPage, 9; got != want {
+		t.Errorf("resp.LastPage: %v, want %v", got, want)
+	}
+	if got, want := resp.NextPageToken, "MDQ6VXNlcjQ2"; got != want {
+		t.Errorf("resp.NextPageToken: %v, want %v", got, want)
+	}
+}
+
 func TestResponse_populatePageValues_invalid(t *testing.T) {
 	r := http.Response{
 		Header: http.Header{
@@ -1013,24 +1525,422 @@ func TestDo(t *testing.T) {
 	}
 }
 
-func TestDo_nilContext(t *testing.T) {
-	client, _, _, teardown := setup()
+func TestDo_ioWriter(t *testing.T) {
+	client, mux, _, teardown := setup()
 	defer teardown()
 
+	// A PNG signature followed by bytes that are not valid JSON.
+	want := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "image/png")
+		assertWrite(t, w, want)
+	})
+
 	req, _ := client.NewRequest("GET", ".", nil)
-	_, err := client.Do(nil, req, nil)
+	buf := new(bytes.Buffer)
+	ctx := context.Background()
+	_, err := client.Do(ctx, req, buf)
+	assertNilError(t, err)
 
-	if !errors.Is(err, errNonNilContext) {
-		t.Errorf("Expected context must be non-nil error")
+	if got := buf.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("Response body = %v, want %v", got, want)
 	}
 }
 
-func TestDo_httpError(t *testing.T) {
+func TestDo_WithMediaType_rawBody(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
 
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		http.Error(w, "Bad Request", 400)
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "application/vnd.github.raw")
+		fmt.Fprint(w, "# Hello\nnot json")
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil, WithMediaType("application/vnd.github.raw"))
+	buf := new(bytes.Buffer)
+	ctx := context.Background()
+	_, err := client.Do(ctx, req, buf)
+	assertNilError(t, err)
+
+	if got, want := buf.String(), "# Hello\nnot json"; got != want {
+		t.Errorf("Response body = %q, want %q", got, want)
+	}
+}
+
+func TestDo_Logger(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"login":"u"}`)
+	})
+
+	var calls int
+	client.Logger = func(req *http.Request, resp *http.Response, err error) {
+		calls++
+		if got, want := req.Method, "GET"; got != want {
+			t.Errorf("Logger request method = %v, want %v", got, want)
+		}
+		if got, want := req.URL.Path, baseURLPath+"/users/u"; got != want {
+			t.Errorf("Logger request path = %v, want %v", got, want)
+		}
+		if err != nil {
+			t.Errorf("Logger err = %v, want nil", err)
+		}
+		if got, want := resp.StatusCode, http.StatusOK; got != want {
+			t.Errorf("Logger response status = %v, want %v", got, want)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if got, want := string(body), `{"login":"u"}`; got != want {
+			t.Errorf("Logger response body = %v, want %v", got, want)
+		}
+	}
+
+	req, _ := client.NewRequest("GET", "users/u", nil)
+	user := new(User)
+	ctx := context.Background()
+	_, err := client.Do(ctx, req, user)
+	assertNilError(t, err)
+
+	if calls != 1 {
+		t.Errorf("Logger called %v times, want 1", calls)
+	}
+	if got, want := user.GetLogin(), "u"; got != want {
+		t.Errorf("Do decoded login %q, want %q", got, want)
+	}
+}
+
+func TestDo_Logger_largeBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	body := strings.Repeat("x", maxLoggedBodySize+10)
+	mux.HandleFunc("/archive", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	var logged []byte
+	client.Logger = func(req *http.Request, resp *http.Response, err error) {
+		logged, _ = io.ReadAll(resp.Body)
+	}
+
+	req, _ := client.NewRequest("GET", "archive", nil)
+	buf := new(bytes.Buffer)
+	_, err := client.Do(context.Background(), req, buf)
+	assertNilError(t, err)
+
+	if got, want := len(logged), maxLoggedBodySize; got != want {
+		t.Errorf("Logger got %v bytes of body, want %v", got, want)
+	}
+	if got := buf.String(); got != body {
+		t.Errorf("Do wrote %v bytes, want the whole body of %v bytes", len(got), len(body))
+	}
+}
+
+func TestDo_Logger_error(t *testing.T) {
+	client, _, _, teardown := setup()
+	teardown()
+
+	var gotErr error
+	client.Logger = func(req *http.Request, resp *http.Response, err error) {
+		if resp != nil {
+			t.Errorf("Logger response = %#v, want nil", resp)
+		}
+		gotErr = err
+	}
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	ctx := context.Background()
+	if _, err := client.Do(ctx, req, nil); err == nil {
+		t.Fatal("Do returned nil error, want error")
+	}
+	if gotErr == nil {
+		t.Error("Logger was not called with the transport error")
+	}
+}
+
+func TestDo_gzip(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept-Encoding", "gzip")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, `{"id":1,"login":"u"}`)
+		zw.Close()
+	})
+
+	user, resp, err := client.Users.Get(context.Background(), "u")
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+
+	want := &User{ID: Int64(1), Login: String("u")}
+	if !cmp.Equal(user, want, cmpUsers) {
+		t.Errorf("Users.Get returned %+v, want %+v", user, want)
+	}
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("Response Content-Encoding is %q, want it removed after decompression", got)
+	}
+}
+
+// Ensure that requests made by NewRequest but sent by another http.Client
+// keep the transparent decompression of net/http.
+func TestNewRequest_gzipOtherClient(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept-Encoding", "gzip")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, `{"id":1}`)
+		zw.Close()
+	})
+
+	req, err := client.NewRequest("GET", "users/u", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		t.Fatalf("http.Client.Do returned error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if got, want := string(body), `{"id":1}`; got != want {
+		t.Errorf("body is %q, want %q", got, want)
+	}
+}
+
+func TestDo_gzipEmptyBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/following/u", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Users.Follow(context.Background(), "u"); err != nil {
+		t.Errorf("Users.Follow returned error: %v", err)
+	}
+}
+
+func TestDo_DisableCompression(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept-Encoding", "identity")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	client.DisableCompression = true
+	user, _, err := client.Users.Get(context.Background(), "u")
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if want := (&User{ID: Int64(1)}); !cmp.Equal(user, want, cmpUsers) {
+		t.Errorf("Users.Get returned %+v, want %+v", user, want)
+	}
+}
+
+func TestDo_DryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var patched bool
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			patched = true
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	var logged []string
+	client.DryRun = true
+	client.Logger = func(req *http.Request, resp *http.Response, err error) {
+		logged = append(logged, fmt.Sprintf("%v %v %v", req.Method, req.URL.Path, resp.StatusCode))
+	}
+
+	ctx := context.Background()
+	req, _ := client.NewRequest("PATCH", "user", &User{Name: String("n")})
+	user := new(User)
+	resp, err := client.Do(ctx, req, user)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if patched {
+		t.Error("PATCH request was sent in dry-run mode")
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Response status is %v, want %v", resp.StatusCode, http.StatusNoContent)
+	}
+	if !cmp.Equal(user, new(User), cmpUsers) {
+		t.Errorf("Do decoded %+v from a dry-run response, want an empty User", user)
+	}
+
+	// GET requests are still sent.
+	if _, _, err := client.Users.Get(ctx, ""); err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+
+	want := []string{"PATCH " + baseURLPath + "/user 204", "GET " + baseURLPath + "/user 200"}
+	if !cmp.Equal(logged, want) {
+		t.Errorf("Logger got %v, want %v", logged, want)
+	}
+}
+
+func TestDo_MaxBodySize(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		body    string
+		limit   int64
+		logger  bool
+		wantErr bool
+	}{
+		{name: "unlimited", body: `{"A":"abcdefghij"}`},
+		{name: "under the limit", body: `{"A":"a"}`, limit: 18},
+		{name: "at the limit", body: `{"A":"abcdefghij"}`, limit: 18},
+		{name: "over the limit", body: `{"A":"abcdefghijk"}`, limit: 18, wantErr: true},
+		{name: "over the limit with logger", body: `{"A":"abcdefghijk"}`, limit: 18, logger: true, wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, test.body)
+			})
+			client.MaxBodySize = test.limit
+			if test.logger {
+				client.Logger = func(*http.Request, *http.Response, error) {}
+			}
+
+			req, _ := client.NewRequest("GET", ".", nil)
+			body := new(struct{ A string })
+			_, err := client.Do(context.Background(), req, body)
+
+			var tooLarge *ResponseTooLargeError
+			if got := errors.As(err, &tooLarge); got != test.wantErr {
+				t.Fatalf("Do returned error %v, want *ResponseTooLargeError: %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				if tooLarge.Limit != test.limit {
+					t.Errorf("ResponseTooLargeError.Limit = %v, want %v", tooLarge.Limit, test.limit)
+				}
+				return
+			}
+			assertNilError(t, err)
+		})
+	}
+}
+
+func TestDo_OnRequestComplete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+
+	type call struct {
+		method, path string
+		status       int
+	}
+	calls := make(chan call, 1)
+	client.OnRequestComplete = func(method, path string, status int, latency time.Duration) {
+		if latency <= 0 {
+			t.Errorf("OnRequestComplete latency = %v, want > 0", latency)
+		}
+		calls <- call{method, path, status}
+	}
+
+	ctx := context.Background()
+	req, _ := client.NewRequest("GET", "users/u", nil)
+	if _, err := client.Do(ctx, req, nil); err == nil {
+		t.Fatal("Do returned nil error, want error")
+	}
+	if got, want := <-calls, (call{"GET", baseURLPath + "/users/u", http.StatusNotFound}); got != want {
+		t.Errorf("OnRequestComplete called with %+v, want %+v", got, want)
+	}
+
+	// Transport failures are reported with a status of 0.
+	teardown()
+	req, _ = client.NewRequest("DELETE", "users/u", nil)
+	if _, err := client.Do(ctx, req, nil); err == nil {
+		t.Fatal("Do returned nil error, want error")
+	}
+	if got, want := <-calls, (call{"DELETE", baseURLPath + "/users/u", 0}); got != want {
+		t.Errorf("OnRequestComplete called with %+v, want %+v", got, want)
+	}
+}
+
+func TestDo_generic(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	type foo struct {
+		A string
+	}
+
+	mux.HandleFunc("/foo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"page": "2"})
+		testBody(t, r, `{"A":"in"}`+"\n")
+		fmt.Fprint(w, `{"A":"out"}`)
+	})
+
+	ctx := context.Background()
+	got, _, err := do[foo](ctx, client, "POST", "foo", &foo{"in"}, &ListOptions{Page: 2})
+	assertNilError(t, err)
+
+	want := &foo{"out"}
+	if !cmp.Equal(got, want) {
+		t.Errorf("do returned %+v, want %+v", got, want)
+	}
+
+	const methodName = "do"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = do[foo](ctx, client, "GET", "foo", nil, "bad options")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := do[foo](ctx, client, "GET", "foo", nil, nil)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestDo_nilContext(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	_, err := client.Do(nil, req, nil)
+
+	if !errors.Is(err, errNonNilContext) {
+		t.Errorf("Expected context must be non-nil error")
+	}
+}
+
+func TestDo_httpError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Bad Request", 400)
 	})
 
 	req, _ := client.NewRequest("GET", ".", nil)
@@ -1045,6 +1955,39 @@ func TestDo_httpError(t *testing.T) {
 	}
 }
 
+func TestDo_responseHeader(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Custom-Header", "ok")
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/bad", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Custom-Header", "bad")
+		http.Error(w, "Bad Request", 400)
+	})
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "ok", want: "ok"},
+		{path: "bad", want: "bad", wantErr: true},
+	} {
+		req, _ := client.NewRequest("GET", test.path, nil)
+		resp, err := client.Do(ctx, req, nil)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Do(%v) returned error %v, want error: %v", test.path, err, test.wantErr)
+		}
+		if got := resp.Header.Get("X-Custom-Header"); got != test.want {
+			t.Errorf("Do(%v) returned X-Custom-Header %q, want %q", test.path, got, test.want)
+		}
+	}
+}
+
 // Test handling of an error caused by the internal http client's Do()
 // function. A redirect loop is pretty unlikely to occur within the GitHub
 // API, but does allow us to exercise the right code path.
@@ -1068,6 +2011,61 @@ func TestDo_redirectLoop(t *testing.T) {
 	}
 }
 
+func TestDo_permanentRedirect(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, baseURLPath+"/users/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/users/new", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"new"}`)
+	})
+
+	ctx := context.Background()
+	user, resp, err := client.Users.Get(ctx, "old")
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if want := (&User{Login: String("new")}); !cmp.Equal(user, want, cmpUsers) {
+		t.Errorf("Users.Get returned %+v, want %+v", user, want)
+	}
+	if resp.FinalURL == nil {
+		t.Fatal("Response.FinalURL is nil, want the redirect target")
+	}
+	if got, want := resp.FinalURL.String(), client.BaseURL.String()+"users/new"; got != want {
+		t.Errorf("Response.FinalURL = %v, want %v", got, want)
+	}
+
+	// Requests that are not redirected have no FinalURL.
+	if _, resp, err := client.Users.Get(ctx, "new"); err != nil || resp.FinalURL != nil {
+		t.Errorf("Users.Get returned FinalURL %v, err %v; want neither", resp.FinalURL, err)
+	}
+}
+
+func TestDo_temporaryRedirect(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, baseURLPath+"/users/moved", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/users/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, baseURLPath+"/users/new", http.StatusFound)
+	})
+	mux.HandleFunc("/users/new", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"new"}`)
+	})
+
+	_, resp, err := client.Users.Get(context.Background(), "old")
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if resp.FinalURL != nil {
+		t.Errorf("Response.FinalURL = %v, want nil after a temporary redirect", resp.FinalURL)
+	}
+}
+
 // Test that an error caused by the internal http client's Do() function
 // does not leak the client secret.
 func TestDo_sanitizeURL(t *testing.T) {
@@ -1230,26 +2228,133 @@ func TestDo_rateLimit_rateLimitError(t *testing.T) {
 }`)
 	})
 
-	req, _ := client.NewRequest("GET", ".", nil)
-	ctx := context.Background()
-	_, err := client.Do(ctx, req, nil)
+	req, _ := client.NewRequest("GET", ".", nil)
+	ctx := context.Background()
+	_, err := client.Do(ctx, req, nil)
+
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	rateLimitErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("Expected a *RateLimitError error; got %#v.", err)
+	}
+	if got, want := rateLimitErr.Rate.Limit, 60; got != want {
+		t.Errorf("rateLimitErr rate limit = %v, want %v", got, want)
+	}
+	if got, want := rateLimitErr.Rate.Remaining, 0; got != want {
+		t.Errorf("rateLimitErr rate remaining = %v, want %v", got, want)
+	}
+	reset := time.Date(2013, time.July, 1, 17, 47, 53, 0, time.UTC)
+	if rateLimitErr.Rate.Reset.UTC() != reset {
+		t.Errorf("rateLimitErr rate reset = %v, want %v", rateLimitErr.Rate.Reset.UTC(), reset)
+	}
+}
+
+// Ensure that, with WithRateLimitGate, requests beyond the threshold wait for
+// the rate limit to reset instead of failing.
+func TestWithRateLimitGate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client = client.WithRateLimitGate(1)
+
+	// Rate reset is 0.5 to 1.5 seconds from now, with 1 second precision.
+	reset := time.Now().Add(500 * time.Millisecond).Truncate(time.Second).Add(time.Second)
+
+	var mu sync.Mutex
+	var hits []time.Time
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits = append(hits, time.Now())
+		mu.Unlock()
+		w.Header().Set(headerRateLimit, "60")
+		w.Header().Set(headerRateRemaining, "1")
+		w.Header().Set(headerRateReset, fmt.Sprint(reset.Unix()))
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	client.rateLimits[coreCategory] = Rate{Limit: 60, Remaining: 3, Reset: Timestamp{reset}}
+
+	// Only two requests fit before the threshold is reached; the others
+	// have to wait for the reset.
+	const n = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Users.Get(context.Background(), "u")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assertNilError(t, err)
+	}
+
+	if len(hits) != n {
+		t.Fatalf("server received %v requests, want %v", len(hits), n)
+	}
+	var early int
+	for _, hit := range hits {
+		if hit.Before(reset) {
+			early++
+		}
+	}
+	if early != 2 {
+		t.Errorf("server received %v requests before the rate limit reset, want 2", early)
+	}
+}
+
+// Ensure that a request waiting for the rate limit reset gives up when its
+// context is done, without making a network call.
+func TestWithRateLimitGate_contextCanceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client = client.WithRateLimitGate(0)
+
+	madeNetworkCall := false
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		madeNetworkCall = true
+	})
+
+	reset := time.Now().Add(time.Minute)
+	client.rateLimits[coreCategory] = Rate{Limit: 60, Remaining: 0, Reset: Timestamp{reset}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err := client.Users.Get(ctx, "u")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Users.Get returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if madeNetworkCall {
+		t.Error("Network call was made, even though rate limit is known to still be exceeded.")
+	}
+}
+
+// Ensure that, with WithRateLimitGate, a network call is not made while a
+// secondary rate limit is known to still be in effect.
+func TestWithRateLimitGate_secondaryRateLimit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client = client.WithRateLimitGate(0)
+
+	madeNetworkCall := false
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		madeNetworkCall = true
+	})
+
+	client.secondaryRateLimitReset = time.Now().Add(time.Minute)
 
-	if err == nil {
-		t.Error("Expected error to be returned.")
-	}
-	rateLimitErr, ok := err.(*RateLimitError)
-	if !ok {
-		t.Fatalf("Expected a *RateLimitError error; got %#v.", err)
-	}
-	if got, want := rateLimitErr.Rate.Limit, 60; got != want {
-		t.Errorf("rateLimitErr rate limit = %v, want %v", got, want)
-	}
-	if got, want := rateLimitErr.Rate.Remaining, 0; got != want {
-		t.Errorf("rateLimitErr rate remaining = %v, want %v", got, want)
+	_, _, err := client.Users.Get(context.Background(), "u")
+	var abuseErr *AbuseRateLimitError
+	if !errors.As(err, &abuseErr) {
+		t.Errorf("Users.Get returned error %v, want an *AbuseRateLimitError", err)
 	}
-	reset := time.Date(2013, time.July, 1, 17, 47, 53, 0, time.UTC)
-	if rateLimitErr.Rate.Reset.UTC() != reset {
-		t.Errorf("rateLimitErr rate reset = %v, want %v", rateLimitErr.Rate.Reset.UTC(), reset)
+	if madeNetworkCall {
+		t.Error("Network call was made, even though secondary rate limit is known to still be in effect.")
 	}
 }
 
@@ -1478,6 +2583,37 @@ func TestDo_rateLimit_abuseRateLimitError_retryAfter(t *testing.T) {
 	}
 }
 
+// Ensure *AbuseRateLimitError is returned when only the message body indicates
+// that a secondary rate limit was exceeded.
+func TestDo_rateLimit_abuseRateLimitError_message(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set(headerRetryAfter, "30")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintln(w, `{
+   "message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."
+}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	ctx := context.Background()
+	_, err := client.Do(ctx, req, nil)
+
+	abuseRateLimitErr, ok := err.(*AbuseRateLimitError)
+	if !ok {
+		t.Fatalf("Expected a *AbuseRateLimitError error; got %#v.", err)
+	}
+	if abuseRateLimitErr.RetryAfter == nil {
+		t.Fatalf("abuseRateLimitErr RetryAfter is nil, expected not-nil")
+	}
+	if got, want := *abuseRateLimitErr.RetryAfter, 30*time.Second; got != want {
+		t.Errorf("abuseRateLimitErr RetryAfter = %v, want %v", got, want)
+	}
+}
+
 // Ensure *AbuseRateLimitError.RetryAfter is parsed correctly for the x-ratelimit-reset header.
 func TestDo_rateLimit_abuseRateLimitError_xRateLimitReset(t *testing.T) {
 	client, mux, _, teardown := setup()
@@ -1554,6 +2690,64 @@ func TestDo_noContent(t *testing.T) {
 	}
 }
 
+func TestDo_bodylessStatus(t *testing.T) {
+	for _, status := range []int{http.StatusNoContent, http.StatusResetContent} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client, _, _, teardown := setup()
+			defer teardown()
+
+			// Serve a stray body, which a well-behaved server would not send,
+			// to check that Do does not try to decode it.
+			client.client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: status,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(strings.NewReader("not json")),
+					Request:    req,
+				}, nil
+			})
+
+			user := &User{Login: String("u")}
+			req, _ := client.NewRequest("PUT", "user/following/u", nil)
+			resp, err := client.Do(context.Background(), req, user)
+			if err != nil {
+				t.Fatalf("Do returned unexpected error: %v", err)
+			}
+			if resp == nil || resp.StatusCode != status {
+				t.Errorf("Do returned response %+v, want status %v", resp, status)
+			}
+			if want := (&User{Login: String("u")}); !cmp.Equal(user, want, cmpUsers) {
+				t.Errorf("Do decoded into v: got %+v, want %+v", user, want)
+			}
+		})
+	}
+}
+
+func TestDo_acceptedError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	ctx := context.Background()
+	resp, err := client.Do(ctx, req, new(User))
+
+	aerr, ok := err.(*AcceptedError)
+	if !ok {
+		t.Fatalf("Expected an *AcceptedError, got %#v", err)
+	}
+	if got, want := string(aerr.Raw), `{"id":1}`; got != want {
+		t.Errorf("AcceptedError.Raw = %q, want %q", got, want)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Response status code = %v, want %v", resp.StatusCode, http.StatusAccepted)
+	}
+}
+
 func TestSanitizeURL(t *testing.T) {
 	tests := []struct {
 		in, want string
@@ -1601,6 +2795,41 @@ func TestCheckResponse(t *testing.T) {
 	}
 }
 
+func TestDo_unavailableForLegalReasons(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		fmt.Fprint(w, `{
+			"message": "Repository access blocked",
+			"block": {"reason": "dmca", "created_at": "2016-03-17T15:39:46Z"},
+			"documentation_url": "https://docs.github.com/rest"
+		}`)
+	})
+
+	req, _ := client.NewRequest("GET", "repos/o/r", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	var legalErr *UnavailableForLegalReasonsError
+	if !errors.As(err, &legalErr) {
+		t.Fatalf("Expected a *UnavailableForLegalReasonsError error; got %#v", err)
+	}
+	if got, want := legalErr.DocumentationURL, "https://docs.github.com/rest"; got != want {
+		t.Errorf("DocumentationURL = %q, want %q", got, want)
+	}
+	wantBlock := &ErrorBlock{
+		Reason:    "dmca",
+		CreatedAt: &Timestamp{time.Date(2016, time.March, 17, 15, 39, 46, 0, time.UTC)},
+	}
+	if !cmp.Equal(legalErr.Block, wantBlock) {
+		t.Errorf("Block = %+v, want %+v", legalErr.Block, wantBlock)
+	}
+	if got, want := legalErr.Message, "Repository access blocked"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
+
 func TestCheckResponse_RateLimit(t *testing.T) {
 	res := &http.Response{
 		Request:    &http.Request{},
@@ -2116,6 +3345,65 @@ func TestErrorResponse_Error(t *testing.T) {
 	}
 }
 
+func TestErrorResponse_FieldErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"Validation Failed","errors":[
+			{"resource":"User","field":"blog","code":"invalid"},
+			{"resource":"User","field":"email","code":"already_exists"},
+			{"resource":"User","field":"blog","code":"too_long"},
+			{"resource":"User","code":"custom","message":"m"}
+		]}`)
+	})
+
+	_, _, err := client.Self.Edit(context.Background(), &User{Blog: String("b")})
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Self.Edit returned error %v, want *ErrorResponse", err)
+	}
+
+	want := map[string]string{
+		"blog":  "invalid",
+		"email": "already_exists",
+	}
+	if got := errResp.FieldErrors(); !cmp.Equal(got, want) {
+		t.Errorf("FieldErrors returned %+v, want %+v", got, want)
+	}
+
+	if got := (&ErrorResponse{}).FieldErrors(); len(got) != 0 {
+		t.Errorf("FieldErrors with no errors returned %+v, want empty", got)
+	}
+}
+
+func TestDo_requestID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRequestID, "CAFE:1234:5678:9ABC:DEF0")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message":"Server Error"}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err == nil {
+		t.Fatal("Expected HTTP 500 error, got no error.")
+	}
+
+	want := "CAFE:1234:5678:9ABC:DEF0"
+	if resp.RequestID != want {
+		t.Errorf("Response.RequestID = %q, want %q", resp.RequestID, want)
+	}
+	if got := err.Error(); !strings.HasSuffix(got, "Server Error [] (request ID "+want+")") {
+		t.Errorf("Error() = %q, want it to end with the request ID %q", got, want)
+	}
+}
+
 func TestError_Error(t *testing.T) {
 	err := Error{}
 	if err.Error() == "" {
@@ -2417,6 +3705,29 @@ func TestUnauthenticatedRateLimitedTransport(t *testing.T) {
 	assertNilError(t, err)
 }
 
+func TestUnauthenticatedRateLimitedTransport_preservesQuery(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Errorf("request does not contain basic auth credentials")
+		}
+		testFormValues(t, r, values{"since": "1", "per_page": "100"})
+	})
+
+	tp := &UnauthenticatedRateLimitedTransport{
+		ClientID:     "id",
+		ClientSecret: "secret",
+	}
+	unauthedClient := NewClient(tp.Client())
+	unauthedClient.BaseURL = client.BaseURL
+	req, _ := unauthedClient.NewRequest("GET", "users?since=1&per_page=100", nil)
+	ctx := context.Background()
+	_, err := unauthedClient.Do(ctx, req, nil)
+	assertNilError(t, err)
+}
+
 func TestUnauthenticatedRateLimitedTransport_missingFields(t *testing.T) {
 	// missing ClientID
 	tp := &UnauthenticatedRateLimitedTransport{
@@ -2493,6 +3804,31 @@ func TestBasicAuthTransport(t *testing.T) {
 	assertNilError(t, err)
 }
 
+func TestBasicAuthTransport_noOTP(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Errorf("request does not contain basic auth credentials")
+		}
+		if got, ok := r.Header[headerOTP]; ok {
+			t.Errorf("request contained OTP %q, want none", got)
+		}
+	})
+
+	tp := &BasicAuthTransport{
+		Username: "u",
+		Password: "p",
+	}
+	basicAuthClient := NewClient(tp.Client())
+	basicAuthClient.BaseURL = client.BaseURL
+	req, _ := basicAuthClient.NewRequest("GET", ".", nil)
+	ctx := context.Background()
+	_, err := basicAuthClient.Do(ctx, req, nil)
+	assertNilError(t, err)
+}
+
 func TestBasicAuthTransport_transport(t *testing.T) {
 	// default transport
 	tp := &BasicAuthTransport{}
@@ -2573,6 +3909,47 @@ func TestTwoFactorAuthError(t *testing.T) {
 	}
 }
 
+func TestDo_twoFactorAuthError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerOTP, "required; sms")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message": "Must specify two-factor authentication OTP code."}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	ctx := context.Background()
+	_, err := client.Do(ctx, req, nil)
+
+	tfaErr, ok := err.(*TwoFactorAuthError)
+	if !ok {
+		t.Fatalf("Expected a *TwoFactorAuthError error; got %#v.", err)
+	}
+	if got, want := tfaErr.Method(), "sms"; got != want {
+		t.Errorf("TwoFactorAuthError.Method() = %q, want %q", got, want)
+	}
+}
+
+func TestTwoFactorAuthError_Method(t *testing.T) {
+	for header, want := range map[string]string{
+		"required; app": "app",
+		"required; sms": "sms",
+		"required":      "",
+	} {
+		e := &TwoFactorAuthError{Response: &http.Response{Header: http.Header{}}}
+		e.Response.Header.Set(headerOTP, header)
+		if got := e.Method(); got != want {
+			t.Errorf("TwoFactorAuthError.Method() for header %q = %q, want %q", header, got, want)
+		}
+	}
+
+	if got := (&TwoFactorAuthError{}).Method(); got != "" {
+		t.Errorf("TwoFactorAuthError.Method() without response = %q, want empty", got)
+	}
+}
+
 func TestRateLimitError(t *testing.T) {
 	u, err := url.Parse("https://example.com")
 	if err != nil {
@@ -2622,6 +3999,124 @@ func TestAddOptions_QueryValues(t *testing.T) {
 	}
 }
 
+func TestAddOptions_DateRangeOptions(t *testing.T) {
+	since := &Timestamp{time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)}
+	until := &Timestamp{time.Date(2023, time.February, 3, 4, 5, 6, 0, time.FixedZone("CET", 3600))}
+	for _, test := range []struct {
+		opts *DateRangeOptions
+		want string
+	}{
+		{nil, "events"},
+		{&DateRangeOptions{}, "events"},
+		{&DateRangeOptions{Since: since}, "events?since=2023-01-02T03%3A04%3A05Z"},
+		{&DateRangeOptions{Until: until}, "events?until=2023-02-03T04%3A05%3A06%2B01%3A00"},
+		{&DateRangeOptions{Since: since, Until: until}, "events?since=2023-01-02T03%3A04%3A05Z&until=2023-02-03T04%3A05%3A06%2B01%3A00"},
+	} {
+		got, err := addOptions("events", test.opts)
+		if err != nil {
+			t.Errorf("addOptions(%+v) returned error: %v", test.opts, err)
+		}
+		if got != test.want {
+			t.Errorf("addOptions(%+v) = %v, want %v", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestAddOptions_SortOptions(t *testing.T) {
+	type listOptions struct {
+		SortOptions
+		ListOptions
+	}
+	for _, test := range []struct {
+		opts *listOptions
+		want string
+	}{
+		{&listOptions{}, "starred"},
+		{&listOptions{SortOptions: SortOptions{Sort: "created"}}, "starred?sort=created"},
+		{&listOptions{SortOptions: SortOptions{Sort: "updated", Direction: "asc"}, ListOptions: ListOptions{Page: 2}}, "starred?direction=asc&page=2&sort=updated"},
+	} {
+		got, err := addOptions("starred", test.opts)
+		if err != nil {
+			t.Errorf("addOptions(%+v) returned error: %v", test.opts, err)
+		}
+		if got != test.want {
+			t.Errorf("addOptions(%+v) = %v, want %v", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestAddOptions_exported(t *testing.T) {
+	since := &Timestamp{time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)}
+	for _, test := range []struct {
+		opts interface{}
+		want string
+	}{
+		{nil, "users"},
+		{(*UserListOptions)(nil), "users"},
+		{&UserListOptions{Since: 5, ListOptions: ListOptions{PerPage: 10}}, "users?per_page=10&since=5"},
+		{&DateRangeOptions{Since: since}, "users?since=2023-01-02T03%3A04%3A05Z"},
+		{IssueListOptions{Labels: []string{"a", "b"}, Since: since.Time}, "users?labels=a%2Cb&since=2023-01-02T03%3A04%3A05Z"},
+	} {
+		got, err := AddOptions("users", test.opts)
+		if err != nil {
+			t.Errorf("AddOptions(%+v) returned error: %v", test.opts, err)
+		}
+		if got != test.want {
+			t.Errorf("AddOptions(%+v) = %v, want %v", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestAddOptions_invalid(t *testing.T) {
+	for _, opts := range []interface{}{
+		"",
+		&struct {
+			Filters map[string]string `url:"filters,omitempty"`
+		}{Filters: map[string]string{"a": "b"}},
+		&struct {
+			Users []*User `url:"users,omitempty"`
+		}{},
+		&struct {
+			Nested struct {
+				Callback func() `url:"callback"`
+			} `url:"nested"`
+		}{},
+		&struct {
+			PerPage int `url:"per_page,omitemtpy"`
+		}{},
+	} {
+		if got, err := AddOptions("users", opts); err == nil {
+			t.Errorf("AddOptions(%#v) = %v, want error", opts, got)
+		}
+		if !debugOptions {
+			// Without the github_debug tag, the unexported helper keeps
+			// going as it always has.
+			if _, err := addOptions("users", opts); err != nil && opts != "" {
+				t.Errorf("addOptions(%#v) returned error: %v", opts, err)
+			}
+		}
+	}
+}
+
+func TestSortOptions_validate(t *testing.T) {
+	for _, test := range []struct {
+		opts    *SortOptions
+		wantErr bool
+	}{
+		{nil, false},
+		{&SortOptions{}, false},
+		{&SortOptions{Sort: "created"}, false},
+		{&SortOptions{Direction: "asc"}, false},
+		{&SortOptions{Direction: "desc"}, false},
+		{&SortOptions{Direction: "DESC"}, true},
+		{&SortOptions{Direction: "up"}, true},
+	} {
+		if err := test.opts.validate(); (err != nil) != test.wantErr {
+			t.Errorf("validate(%+v) returned error %v, want error: %v", test.opts, err, test.wantErr)
+		}
+	}
+}
+
 func TestBareDo_returnsOpenBody(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -2922,3 +4417,100 @@ func TestParseTokenExpiration(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_LastTokenExpiration(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/expiring", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerTokenExpiration, "2021-09-03 02:34:04 UTC")
+	})
+	mux.HandleFunc("/cached", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-From-Cache", "1")
+	})
+	mux.HandleFunc("/forever", func(w http.ResponseWriter, r *http.Request) {})
+
+	if got := client.LastTokenExpiration(); got != nil {
+		t.Errorf("LastTokenExpiration before any request = %v, want nil", got)
+	}
+
+	ctx := context.Background()
+	want := time.Date(2021, time.September, 3, 2, 34, 4, 0, time.UTC)
+	for _, test := range []struct {
+		path string
+		want *time.Time
+	}{
+		{path: "expiring", want: &want},
+		{path: "cached", want: &want},
+		{path: "forever", want: nil},
+	} {
+		req, _ := client.NewRequest("GET", test.path, nil)
+		resp, err := client.Do(ctx, req, nil)
+		if err != nil {
+			t.Fatalf("Do(%v) returned error: %v", test.path, err)
+		}
+		if test.path == "expiring" && !resp.TokenExpiration.Time.Equal(want) {
+			t.Errorf("Do(%v) returned TokenExpiration %v, want %v", test.path, resp.TokenExpiration, want)
+		}
+		got := client.LastTokenExpiration()
+		if (got == nil) != (test.want == nil) || (got != nil && !got.Equal(*test.want)) {
+			t.Errorf("LastTokenExpiration after %v = %v, want %v", test.path, got, test.want)
+		}
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		header []string
+		want   []string
+	}{
+		{header: nil, want: nil},
+		{header: []string{""}, want: []string{}},
+		{header: []string{"repo"}, want: []string{"repo"}},
+		{header: []string{"repo, user:email,read:org"}, want: []string{"repo", "user:email", "read:org"}},
+	}
+
+	for _, tt := range tests {
+		res := &http.Response{Header: http.Header{}}
+		for _, v := range tt.header {
+			res.Header.Add(headerOAuthScopes, v)
+		}
+		if got := parseScopes(res, headerOAuthScopes); !cmp.Equal(got, tt.want) {
+			t.Errorf("parseScopes of %q returned %#v, want %#v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestDo_scopes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/hovercard", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerOAuthScopes, "read:org, user")
+		w.Header().Set(headerAcceptedScopes, "repo")
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := client.NewRequest("GET", "users/u/hovercard", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	assertNilError(t, err)
+
+	if want := []string{"read:org", "user"}; !cmp.Equal(resp.TokenScopes, want) {
+		t.Errorf("Response.TokenScopes = %v, want %v", resp.TokenScopes, want)
+	}
+	if want := []string{"repo"}; !cmp.Equal(resp.RequiredScopes, want) {
+		t.Errorf("Response.RequiredScopes = %v, want %v", resp.RequiredScopes, want)
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	errA := errors.New("a failed")
+	err := &MultiError{Errors: map[string]error{"b": errors.New("b failed"), "a": errA}}
+
+	if got, want := err.Error(), "2 errors occurred: a: a failed; b: b failed"; got != want {
+		t.Errorf("MultiError.Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, errA) {
+		t.Errorf("errors.Is(%v, %v) = false, want true", err, errA)
+	}
+}
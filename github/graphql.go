@@ -0,0 +1,217 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GraphQLError occurs when the GraphQL API reports errors in the body of an
+// otherwise successful response, such as for a query naming a user that does
+// not exist.
+type GraphQLError struct {
+	Response *http.Response // HTTP response that carried the errors
+	Errors   []*GraphQLErrorDetail
+}
+
+// GraphQLErrorDetail is an error reported by the GraphQL API.
+type GraphQLErrorDetail struct {
+	// Type is a code classifying the error, such as NOT_FOUND. It is not
+	// set for every error.
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+	// Path is the path of the field the error relates to, made of field
+	// names and list indexes.
+	Path []interface{} `json:"path,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, detail := range e.Errors {
+		messages[i] = detail.Message
+	}
+	return fmt.Sprintf("%v %v: GraphQL errors: %v",
+		e.Response.Request.Method, sanitizeURL(e.Response.Request.URL), strings.Join(messages, "; "))
+}
+
+// graphQLPath returns the path of the GraphQL endpoint relative to the
+// client's BaseURL. GitHub Enterprise Server serves it at /api/graphql,
+// beside rather than beneath the /api/v3/ REST prefix.
+func (c *Client) graphQLPath() string {
+	if strings.HasSuffix(c.BaseURL.Path, "/api/v3/") {
+		return "../graphql"
+	}
+	return "graphql"
+}
+
+// graphQLRequest is the body of a request to the GraphQL endpoint.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the body of a response from the GraphQL endpoint.
+type graphQLResponse struct {
+	Data   json.RawMessage       `json:"data"`
+	Errors []*GraphQLErrorDetail `json:"errors"`
+}
+
+// GraphQL sends query, along with variables, to the GraphQL API and JSON
+// decodes the data of the response into out, unless out is nil. Numbers
+// decoded into an interface{} are json.Number values rather than float64, so
+// that large IDs keep their precision; see ParseID. If the response reports
+// errors, they are returned as a *GraphQLError, and out is left untouched.
+// The GraphQL API requires the client to be authenticated.
+//
+// It is meant for the few features that the REST API lacks; the query and
+// the shape of out are up to the caller.
+//
+// With Client.DryRun, documents whose operations are all queries are still
+// sent, while any other document, such as one holding a mutation, is skipped
+// like other writes, leaving out untouched.
+//
+// GitHub API docs: https://docs.github.com/en/graphql/guides/forming-calls-with-graphql
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) (*Response, error) {
+	req, err := c.NewRequest("POST", c.graphQLPath(), &graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil && isGraphQLReadOnly(query) {
+		ctx = context.WithValue(ctx, readOnly, true)
+	}
+
+	body := new(graphQLResponse)
+	resp, err := c.Do(ctx, req, body)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(body.Errors) > 0 {
+		return resp, &GraphQLError{Response: resp.Response, Errors: body.Errors}
+	}
+	if out != nil && len(body.Data) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(body.Data))
+		dec.UseNumber()
+		if err := dec.Decode(out); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// isGraphQLReadOnly reports whether every operation of the GraphQL document
+// doc is a query, written with the query keyword or as the { ... } shorthand,
+// besides any fragment definitions. Documents with no operation, with a
+// mutation or subscription, or that cannot be scanned are not read-only.
+func isGraphQLReadOnly(doc string) bool {
+	var (
+		depth        int  // Nesting of braces, parentheses and brackets.
+		inDefinition bool // Whether a top-level definition is being scanned.
+		operations   int
+	)
+	for i := 0; i < len(doc); {
+		c := doc[i]
+		switch {
+		case c == '#':
+			// Skip the comment up to the end of its line.
+			for i < len(doc) && doc[i] != '\n' && doc[i] != '\r' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(doc[i:], `"""`):
+			// Skip the block string, in which \""" is an escaped quote.
+			i += 3
+			for !strings.HasPrefix(doc[i:], `"""`) {
+				if i >= len(doc) {
+					return false
+				}
+				if strings.HasPrefix(doc[i:], `\"""`) {
+					i += 3
+				}
+				i++
+			}
+			i += 3
+			continue
+		case c == '"':
+			for i++; i < len(doc) && doc[i] != '"'; i++ {
+				if doc[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(doc) {
+				return false
+			}
+		case c == '{' || c == '(' || c == '[':
+			if depth == 0 && !inDefinition {
+				// A selection set on its own is a query.
+				operations++
+				inDefinition = true
+			}
+			depth++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+			if depth < 0 {
+				return false
+			}
+			if depth == 0 && c == '}' {
+				inDefinition = false
+			}
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			j := i + 1
+			for j < len(doc) && (doc[j] == '_' || 'a' <= doc[j] && doc[j] <= 'z' || 'A' <= doc[j] && doc[j] <= 'Z' || '0' <= doc[j] && doc[j] <= '9') {
+				j++
+			}
+			if depth == 0 && !inDefinition {
+				switch doc[i:j] {
+				case "query":
+					operations++
+				case "fragment":
+				default:
+					return false
+				}
+				inDefinition = true
+			}
+			i = j
+			continue
+		}
+		i++
+	}
+	return operations > 0 && depth == 0 && !inDefinition
+}
+
+// ParseID returns the integer ID held by v, a value decoded from JSON into an
+// interface{}, such as a field of a map filled in by GraphQL. v may be a
+// json.Number or a string of decimal digits, which hold IDs of any size
+// exactly, or an integer. A float64, which is what encoding/json produces
+// unless told to use json.Number, is only accepted if it is an integer below
+// 2^53, above which it may have lost precision.
+func ParseID(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(v.String(), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) >= 1<<53 {
+			return 0, fmt.Errorf("ID %v is not an integer that a float64 holds exactly", v)
+		}
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("ID has unsupported type %T", v)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_GraphQL(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const query = `query($login: String!) { user(login: $login) { name } }`
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "Content-Type", "application/json")
+		testBody(t, r, `{"query":"query($login: String!) { user(login: $login) { name } }","variables":{"login":"u"}}`+"\n")
+		fmt.Fprint(w, `{"data":{"user":{"name":"N"}}}`)
+	})
+
+	var out struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+	ctx := context.Background()
+	resp, err := client.GraphQL(ctx, query, map[string]interface{}{"login": "u"}, &out)
+	if err != nil {
+		t.Fatalf("GraphQL returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GraphQL returned status %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	if out.User.Name != "N" {
+		t.Errorf("GraphQL decoded name %q, want %q", out.User.Name, "N")
+	}
+
+	const methodName = "GraphQL"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.GraphQL(ctx, query, nil, &out)
+	})
+}
+
+func TestClient_GraphQL_errors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"data": {"user": null},
+			"errors": [
+				{"type": "NOT_FOUND", "path": ["user"], "message": "Could not resolve to a User."},
+				{"message": "Something else."}
+			]
+		}`)
+	})
+
+	out := map[string]interface{}{"untouched": true}
+	_, err := client.GraphQL(context.Background(), "query { user(login: \"u\") { name } }", nil, &out)
+
+	var gqlErr *GraphQLError
+	if !errors.As(err, &gqlErr) {
+		t.Fatalf("GraphQL returned error %v, want *GraphQLError", err)
+	}
+	want := []*GraphQLErrorDetail{
+		{Type: "NOT_FOUND", Message: "Could not resolve to a User.", Path: []interface{}{"user"}},
+		{Message: "Something else."},
+	}
+	if !cmp.Equal(gqlErr.Errors, want) {
+		t.Errorf("GraphQLError.Errors = %+v, want %+v", gqlErr.Errors, want)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "Could not resolve to a User.; Something else.") {
+		t.Errorf("GraphQLError.Error() = %q, want it to list both messages", msg)
+	}
+	if want := map[string]interface{}{"untouched": true}; !cmp.Equal(out, want) {
+		t.Errorf("GraphQL decoded %v despite errors, want out untouched", out)
+	}
+}
+
+func TestClient_GraphQL_enterprise(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// WithEnterpriseURLs puts the REST API under /api/v3/; the GraphQL
+	// endpoint sits next to it.
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{}}`)
+	})
+
+	c, err := client.WithEnterpriseURLs(client.BaseURL.String(), client.UploadURL.String())
+	if err != nil {
+		t.Fatalf("WithEnterpriseURLs returned error: %v", err)
+	}
+	resp, err := c.GraphQL(context.Background(), "query { viewer { login } }", nil, nil)
+	if err != nil {
+		t.Fatalf("GraphQL returned error: %v", err)
+	}
+	if got, want := resp.Request.URL.Path, baseURLPath+"/api/graphql"; got != want {
+		t.Errorf("GraphQL requested %v, want %v", got, want)
+	}
+}
+
+func TestClient_GraphQL_largeNumbers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// 2^53 + 1 is the smallest integer a float64 cannot hold.
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"user":{"databaseId":9007199254740993}}}`)
+	})
+
+	var out map[string]interface{}
+	if _, err := client.GraphQL(context.Background(), "query { user { databaseId } }", nil, &out); err != nil {
+		t.Fatalf("GraphQL returned error: %v", err)
+	}
+	user, _ := out["user"].(map[string]interface{})
+	id, err := ParseID(user["databaseId"])
+	if err != nil {
+		t.Fatalf("ParseID returned error: %v", err)
+	}
+	if want := int64(9007199254740993); id != want {
+		t.Errorf("ParseID returned %v, want %v", id, want)
+	}
+}
+
+func TestClient_GraphQL_DryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var sent []string
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		sent = append(sent, body.Query)
+		fmt.Fprint(w, `{"data":{"viewer":{"login":"u"}}}`)
+	})
+
+	client.DryRun = true
+	ctx := context.Background()
+	for _, query := range []string{
+		`{ viewer { login } }`,
+		`query { viewer { login } }`,
+		"# The current user.\nquery Viewer { viewer { login } }",
+		"fragment F on User { login }\nquery { viewer { ...F } }",
+	} {
+		var out struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+		}
+		if _, err := client.GraphQL(ctx, query, nil, &out); err != nil {
+			t.Fatalf("GraphQL(%q) returned error: %v", query, err)
+		}
+		if out.Viewer.Login != "u" {
+			t.Errorf("GraphQL(%q) decoded login %q in dry-run mode, want %q", query, out.Viewer.Login, "u")
+		}
+	}
+
+	for _, mutation := range []string{
+		`mutation { addStar(input: {starrableId: "R"}) { clientMutationId } }`,
+		"fragment F on Starrable { id }\nmutation { addStar(input: {starrableId: \"R\"}) { starrable { ...F } } }",
+	} {
+		resp, err := client.GraphQL(ctx, mutation, nil, nil)
+		if err != nil {
+			t.Fatalf("GraphQL(%q) returned error: %v", mutation, err)
+		}
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("GraphQL(%q) returned status %v in dry-run mode, want %v", mutation, resp.StatusCode, http.StatusNoContent)
+		}
+	}
+	if len(sent) != 4 {
+		t.Errorf("GraphQL sent %v requests in dry-run mode, want the 4 queries only: %q", len(sent), sent)
+	}
+}
+
+func TestIsGraphQLReadOnly(t *testing.T) {
+	for _, test := range []struct {
+		doc  string
+		want bool
+	}{
+		{`{ viewer { login } }`, true},
+		{`query($login: String!) { user(login: $login) { name } }`, true},
+		{"# mutation\nquery { viewer { login } }", true},
+		{`query { user(login: "mutation {") { name } }`, true},
+		{"query { search(query: \"\"\"a \\\"\"\" mutation\"\"\") { issueCount } }", true},
+		{"fragment F on User { login }\nquery A { viewer { ...F } }\nquery B { viewer { login } }", true},
+		{`mutation { addStar(input: {starrableId: "R"}) { clientMutationId } }`, false},
+		{"fragment F on User { login }\nmutation { followUser { user { ...F } } }", false},
+		{"query A { viewer { login } }\nmutation B { addStar { clientMutationId } }", false},
+		{`subscription { starred { id } }`, false},
+		{`fragment F on User { login }`, false},
+		{``, false},
+		{`query { viewer { login }`, false},
+		{`query { user(login: "u) { name } }`, false},
+	} {
+		if got := isGraphQLReadOnly(test.doc); got != test.want {
+			t.Errorf("isGraphQLReadOnly(%q) = %v, want %v", test.doc, got, test.want)
+		}
+	}
+}
+
+func TestParseID(t *testing.T) {
+	for _, test := range []struct {
+		v       interface{}
+		want    int64
+		wantErr bool
+	}{
+		{v: json.Number("9007199254740993"), want: 9007199254740993},
+		{v: "9007199254740993", want: 9007199254740993},
+		{v: int64(9007199254740993), want: 9007199254740993},
+		{v: 42, want: 42},
+		{v: float64(42), want: 42},
+		{v: float64(1 << 53), wantErr: true},
+		{v: 1.5, wantErr: true},
+		{v: json.Number("1.5"), wantErr: true},
+		{v: "abc", wantErr: true},
+		{v: nil, wantErr: true},
+		{v: true, wantErr: true},
+	} {
+		got, err := ParseID(test.v)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseID(%#v) returned error %v, want error: %v", test.v, err, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("ParseID(%#v) = %v, want %v", test.v, got, test.want)
+		}
+	}
+}
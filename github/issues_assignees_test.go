@@ -33,7 +33,7 @@ func TestIssuesService_ListAssignees(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(assignees, want) {
+	if !cmp.Equal(assignees, want, cmpUsers) {
 		t.Errorf("Issues.ListAssignees returned %+v, want %+v", assignees, want)
 	}
 
@@ -193,7 +193,7 @@ func TestIssuesService_AddAssignees(t *testing.T) {
 	}
 
 	want := &Issue{Number: Int(1), Assignees: []*User{{Login: String("user1")}, {Login: String("user2")}}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("Issues.AddAssignees = %+v, want %+v", got, want)
 	}
 
@@ -0,0 +1,185 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// jsonTagExceptions lists struct fields, as "Type.Field", that are allowed to
+// lack a json tag in a struct whose other fields have one, because they are
+// not decoded from the API.
+var jsonTagExceptions = map[string]bool{
+	"RateLimitError.Rate":            true,
+	"RateLimitError.Response":        true,
+	"AbuseRateLimitError.Response":   true,
+	"AbuseRateLimitError.RetryAfter": true,
+}
+
+// parseStructs returns the exported struct types declared in the non-test,
+// non-generated files of the package, keyed by name.
+func parseStructs(t *testing.T) map[string]*ast.StructType {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasPrefix(name, "gen-")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parsing package: %v", err)
+	}
+
+	structs := make(map[string]*ast.StructType)
+	for _, file := range pkgs["github"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok && ts.Name.IsExported() {
+					structs[ts.Name.Name] = st
+				}
+			}
+		}
+	}
+	return structs
+}
+
+// embeddedName returns the name of the type embedded by field, or "" if
+// field is not embedded.
+func embeddedName(field *ast.Field) string {
+	if len(field.Names) > 0 {
+		return ""
+	}
+	typ := field.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// jsonName returns the name field is encoded as in JSON, and whether it has
+// a json tag at all.
+func jsonName(name string, field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return name, false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return name, false
+	}
+	value, ok := reflect.StructTag(tag).Lookup("json")
+	if !ok {
+		return name, false
+	}
+	if n := strings.Split(value, ",")[0]; n != "" {
+		return n, true
+	}
+	return name, true
+}
+
+// promotedNames returns the Go and JSON names of the fields that the struct
+// named typeName promotes to structs embedding it.
+func promotedNames(structs map[string]*ast.StructType, typeName string, seen map[string]bool) (goNames, jsonNames map[string]bool) {
+	goNames, jsonNames = make(map[string]bool), make(map[string]bool)
+	st, ok := structs[typeName]
+	if !ok || seen[typeName] {
+		return goNames, jsonNames
+	}
+	seen[typeName] = true
+	for _, field := range st.Fields.List {
+		if embedded := embeddedName(field); embedded != "" {
+			g, j := promotedNames(structs, embedded, seen)
+			for n := range g {
+				goNames[n] = true
+			}
+			for n := range j {
+				jsonNames[n] = true
+			}
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			goNames[name.Name] = true
+			if n, _ := jsonName(name.Name, field); n != "-" {
+				jsonNames[n] = true
+			}
+		}
+	}
+	return goNames, jsonNames
+}
+
+// TestStructFields guards against exported structs declaring fields that
+// shadow, in Go or in JSON, a field of a struct they embed, and against API
+// structs with fields that lack a json tag. Both make a field silently drop
+// out of encoding or decoding, as happened with Self shadowing fields of User.
+func TestStructFields(t *testing.T) {
+	structs := parseStructs(t)
+	names := make([]string, 0, len(structs))
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, typeName := range names {
+		st := structs[typeName]
+
+		// Collect the fields promoted from embedded structs.
+		promotedGo, promotedJSON := make(map[string]string), make(map[string]string)
+		tagged := false
+		for _, field := range st.Fields.List {
+			if embedded := embeddedName(field); embedded != "" {
+				g, j := promotedNames(structs, embedded, map[string]bool{typeName: true})
+				for n := range g {
+					promotedGo[n] = embedded
+				}
+				for n := range j {
+					promotedJSON[n] = embedded
+				}
+				continue
+			}
+			if _, ok := jsonName("", field); ok {
+				tagged = true
+			}
+		}
+
+		for _, field := range st.Fields.List {
+			if embeddedName(field) != "" {
+				continue
+			}
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+				if embedded, ok := promotedGo[name.Name]; ok {
+					t.Errorf("%v.%v shadows the field of the same name promoted from %v", typeName, name.Name, embedded)
+				}
+				jn, hasTag := jsonName(name.Name, field)
+				if embedded, ok := promotedJSON[jn]; ok && jn != "-" {
+					t.Errorf("%v.%v is encoded as %q, shadowing the field promoted from %v", typeName, name.Name, jn, embedded)
+				}
+				if tagged && !hasTag && !jsonTagExceptions[typeName+"."+name.Name] {
+					t.Errorf("%v.%v has no json tag, unlike the other fields of %v", typeName, name.Name, typeName)
+				}
+			}
+		}
+	}
+}
@@ -25,6 +25,32 @@ func TestMessageMAC_BadHashTypePrefix(t *testing.T) {
 	}
 }
 
+func TestValidateSignature(t *testing.T) {
+	// The example from the GitHub docs on validating webhook deliveries.
+	secret := []byte("It's a Secret to Everybody")
+	const signature = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+
+	for _, test := range []struct {
+		name      string
+		signature string
+		payload   string
+		wantErr   bool
+	}{
+		{name: "valid", signature: signature, payload: "Hello, World!"},
+		{name: "tampered payload", signature: signature, payload: "Hello, World?", wantErr: true},
+		{name: "tampered signature", signature: signature[:len(signature)-1] + "8", payload: "Hello, World!", wantErr: true},
+		{name: "missing signature", payload: "Hello, World!", wantErr: true},
+		{name: "malformed signature", signature: "sha256=zz", payload: "Hello, World!", wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSignature(test.signature, []byte(test.payload), secret)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ValidateSignature returned error %v, want error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidatePayload(t *testing.T) {
 	const defaultBody = `{"yo":true}` // All tests below use the default request body and signature.
 	const defaultSignature = "sha1=126f2c800419c60137ce748d7672e77b65cf16d6"
@@ -529,6 +555,57 @@ func TestParseWebHook(t *testing.T) {
 	}
 }
 
+func TestParseWebHook_userEvents(t *testing.T) {
+	for _, test := range []struct {
+		messageType string
+		payload     string
+		want        interface{}
+	}{
+		{
+			messageType: "watch",
+			payload:     `{"action":"started","repository":{"id":1,"full_name":"o/r"},"sender":{"login":"s","id":2}}`,
+			want: &WatchEvent{
+				Action: String("started"),
+				Repo:   &Repository{ID: Int64(1), FullName: String("o/r")},
+				Sender: &User{Login: String("s"), ID: Int64(2)},
+			},
+		},
+		{
+			messageType: "member",
+			payload:     `{"action":"added","member":{"login":"m","id":3},"repository":{"id":1},"sender":{"login":"s","id":2}}`,
+			want: &MemberEvent{
+				Action: String("added"),
+				Member: &User{Login: String("m"), ID: Int64(3)},
+				Repo:   &Repository{ID: Int64(1)},
+				Sender: &User{Login: String("s"), ID: Int64(2)},
+			},
+		},
+		{
+			messageType: "public",
+			payload:     `{"repository":{"id":1,"private":false},"sender":{"login":"s","id":2}}`,
+			want: &PublicEvent{
+				Repo:   &Repository{ID: Int64(1), Private: Bool(false)},
+				Sender: &User{Login: String("s"), ID: Int64(2)},
+			},
+		},
+	} {
+		t.Run(test.messageType, func(t *testing.T) {
+			got, err := ParseWebHook(test.messageType, []byte(test.payload))
+			if err != nil {
+				t.Fatalf("ParseWebHook returned error: %v", err)
+			}
+			if !cmp.Equal(got, test.want, cmpUsers) {
+				t.Errorf("ParseWebHook returned %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	// GitHub sends no webhook for follows.
+	if _, err := ParseWebHook("follow", []byte("{}")); err == nil {
+		t.Error("ParseWebHook(follow) returned no error, want one")
+	}
+}
+
 func TestAllMessageTypesMapped(t *testing.T) {
 	for _, mt := range MessageTypes() {
 		if obj := EventForType(mt); obj == nil {
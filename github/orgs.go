@@ -130,6 +130,16 @@ func (p Plan) String() string {
 	return Stringify(p)
 }
 
+// RemainingPrivateRepos returns how many more private repositories can be
+// created under the plan, given the number of private repositories already
+// owned. It never returns a negative number.
+func (p *Plan) RemainingPrivateRepos(owned int64) int64 {
+	if remaining := p.GetPrivateRepos() - owned; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
 // OrganizationsListOptions specifies the optional parameters to the
 // OrganizationsService.ListAll method.
 type OrganizationsListOptions struct {
@@ -43,7 +43,7 @@ func TestOrganizationsService_ListMembers(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(members, want) {
+	if !cmp.Equal(members, want, cmpUsers) {
 		t.Errorf("Organizations.ListMembers returned %+v, want %+v", members, want)
 	}
 
@@ -88,7 +88,7 @@ func TestOrganizationsService_ListMembers_public(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(members, want) {
+	if !cmp.Equal(members, want, cmpUsers) {
 		t.Errorf("Organizations.ListMembers returned %+v, want %+v", members, want)
 	}
 }
@@ -614,7 +614,7 @@ func TestOrganizationsService_ListPendingOrgInvitations(t *testing.T) {
 			InvitationTeamURL: String("https://api.github.com/organizations/2/invitations/1/teams"),
 		}}
 
-	if !cmp.Equal(invitations, want) {
+	if !cmp.Equal(invitations, want, cmpUsers) {
 		t.Errorf("Organizations.ListPendingOrgInvitations returned %+v, want %+v", invitations, want)
 	}
 
@@ -831,7 +831,7 @@ func TestOrganizationsService_ListFailedOrgInvitations(t *testing.T) {
 		},
 	}
 
-	if !cmp.Equal(failedInvitations, want) {
+	if !cmp.Equal(failedInvitations, want, cmpUsers) {
 		t.Errorf("Organizations.ListFailedOrgInvitations returned %+v, want %+v", failedInvitations, want)
 	}
 
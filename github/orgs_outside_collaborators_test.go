@@ -38,7 +38,7 @@ func TestOrganizationsService_ListOutsideCollaborators(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(members, want) {
+	if !cmp.Equal(members, want, cmpUsers) {
 		t.Errorf("Organizations.ListOutsideCollaborators returned %+v, want %+v", members, want)
 	}
 
@@ -91,7 +91,7 @@ func TestOrganizationsService_ListPackages(t *testing.T) {
 			SiteAdmin:         Bool(false),
 		},
 	}}
-	if !cmp.Equal(packages, want) {
+	if !cmp.Equal(packages, want, cmpUsers) {
 		t.Errorf("Organizations.ListPackages returned %+v, want %+v", packages, want)
 	}
 
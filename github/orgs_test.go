@@ -467,3 +467,20 @@ func TestPlan_Marshal(t *testing.T) {
 
 	testJSONMarshal(t, o, want)
 }
+
+func TestPlan_RemainingPrivateRepos(t *testing.T) {
+	for _, test := range []struct {
+		plan  *Plan
+		owned int64
+		want  int64
+	}{
+		{nil, 0, 0},
+		{&Plan{}, 0, 0},
+		{&Plan{PrivateRepos: Int64(10)}, 3, 7},
+		{&Plan{PrivateRepos: Int64(10)}, 12, 0},
+	} {
+		if got := test.plan.RemainingPrivateRepos(test.owned); got != test.want {
+			t.Errorf("%+v.RemainingPrivateRepos(%v) = %v, want %v", test.plan, test.owned, got, test.want)
+		}
+	}
+}
@@ -35,7 +35,7 @@ func TestOrganizationsService_ListBlockedUsers(t *testing.T) {
 	}
 
 	want := []*User{{Login: String("octocat")}}
-	if !cmp.Equal(blockedUsers, want) {
+	if !cmp.Equal(blockedUsers, want, cmpUsers) {
 		t.Errorf("Organizations.ListBlockedUsers returned %+v, want %+v", blockedUsers, want)
 	}
 
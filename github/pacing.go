@@ -0,0 +1,90 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PacingTransport is an http.RoundTripper that spaces out write requests
+// (POST, PATCH, PUT and DELETE) so that consecutive writes start at least
+// MinInterval apart, plus a random jitter of up to Jitter. Read requests pass
+// through without delay.
+//
+// Pacing writes helps bulk operations, such as following many users in a
+// loop, stay clear of GitHub's secondary rate limits rather than reacting to
+// them once they trip. Waiting honors the request context; a write canceled
+// while waiting does not hold back the ones after it.
+type PacingTransport struct {
+	// Transport is the underlying transport used to make requests.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// MinInterval is the minimum time between the start of two write requests.
+	MinInterval time.Duration
+
+	// Jitter is the upper bound of a random delay added to MinInterval.
+	Jitter time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest time the next write request may start.
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *PacingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if !isWriteMethod(req.Method) {
+		return transport.RoundTrip(req)
+	}
+
+	for {
+		wait := t.tryStart()
+		if wait <= 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return transport.RoundTrip(req)
+}
+
+// tryStart takes the slot of a write request starting now, pushing back the
+// one after it, and returns 0. If the slot is not free yet, it returns how
+// long to wait before trying again instead.
+func (t *PacingTransport) tryStart() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if wait := t.next.Sub(now); wait > 0 {
+		return wait
+	}
+	interval := t.MinInterval
+	if t.Jitter > 0 {
+		interval += time.Duration(rand.Int63n(int64(t.Jitter)))
+	}
+	t.next = now.Add(interval)
+	return 0
+}
+
+// isWriteMethod reports whether method is one that modifies resources.
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
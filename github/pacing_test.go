@@ -0,0 +1,95 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPacingTransport_writes(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/following/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := base.copy()
+	const interval = 20 * time.Millisecond
+	pacing := &PacingTransport{
+		Transport:   client.client.Transport,
+		MinInterval: interval,
+	}
+	client.client.Transport = pacing
+	client.initialize()
+
+	// Record the slot each write takes, which ends when the next may start.
+	ctx := context.Background()
+	var slots []time.Time
+	for _, user := range []string{"a", "b", "c", "d"} {
+		if _, err := client.Users.Follow(ctx, user); err != nil {
+			t.Fatalf("Users.Follow returned error: %v", err)
+		}
+		slots = append(slots, pacing.next)
+	}
+	for i := 1; i < len(slots); i++ {
+		if gap := slots[i].Sub(slots[i-1]); gap < interval {
+			t.Errorf("write %v started %v after the previous one, want at least %v", i, gap, interval)
+		}
+	}
+
+	// Reads never take a slot, so they neither wait nor delay writes.
+	next := pacing.next
+	for _, user := range []string{"a", "b", "c"} {
+		if _, _, err := client.Users.IsFollowing(ctx, "", user); err != nil {
+			t.Fatalf("Users.IsFollowing returned error: %v", err)
+		}
+	}
+	if !pacing.next.Equal(next) {
+		t.Errorf("reads moved the next write slot from %v to %v, want it unchanged", next, pacing.next)
+	}
+}
+
+func TestPacingTransport_contextCanceled(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/following/u", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := base.copy()
+	pacing := &PacingTransport{
+		Transport:   client.client.Transport,
+		MinInterval: time.Hour,
+	}
+	client.client.Transport = pacing
+	client.initialize()
+
+	if _, err := client.Users.Follow(context.Background(), "u"); err != nil {
+		t.Fatalf("Users.Follow returned error: %v", err)
+	}
+	next := pacing.next
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.Users.Follow(ctx, "u"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Users.Follow returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The canceled write did not take a slot.
+	if !pacing.next.Equal(next) {
+		t.Errorf("next write slot is %v after the canceled write, want %v", pacing.next, next)
+	}
+}
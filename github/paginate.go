@@ -0,0 +1,47 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import "context"
+
+// maxPerPage is the largest page size that the API accepts.
+const maxPerPage = 100
+
+// CollectPages calls fn for each page of a list, starting from the first, and
+// returns the items of every page, up to max. Once max items are collected,
+// no further pages are fetched and the last page is trimmed so that exactly
+// max items are returned. A max of zero or less means no limit, which for
+// lists such as the followers of a popular account may be a lot of requests.
+//
+// fn is typically a closure around a list method taking *ListOptions, such as
+//
+//	followers, err := github.CollectPages(ctx, func(ctx context.Context, opts *github.ListOptions) ([]*github.User, *github.Response, error) {
+//		return client.Users.ListFollowers(ctx, "octocat", opts)
+//	}, 500)
+//
+// The first error encountered is returned, along with no items.
+func CollectPages[T any](ctx context.Context, fn func(context.Context, *ListOptions) ([]T, *Response, error), max int) ([]T, error) {
+	opts := &ListOptions{PerPage: maxPerPage}
+	if max > 0 && max < maxPerPage {
+		opts.PerPage = max
+	}
+
+	var all []T
+	for {
+		items, resp, err := fn(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if max > 0 && len(all) >= max {
+			return all[:max], nil
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pagedInts returns a list function serving the integers from 1 to n in
+// pages of the requested size, and a pointer to the number of calls made.
+func pagedInts(n int) (func(context.Context, *ListOptions) ([]int, *Response, error), *int) {
+	calls := 0
+	return func(_ context.Context, opts *ListOptions) ([]int, *Response, error) {
+		calls++
+		page := opts.Page
+		if page == 0 {
+			page = 1
+		}
+		var items []int
+		for i := (page-1)*opts.PerPage + 1; i <= page*opts.PerPage && i <= n; i++ {
+			items = append(items, i)
+		}
+		resp := &Response{}
+		if page*opts.PerPage < n {
+			resp.NextPage = page + 1
+		}
+		return items, resp, nil
+	}, &calls
+}
+
+func TestCollectPages(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		name      string
+		n, max    int
+		wantLen   int
+		wantCalls int
+	}{
+		{name: "unlimited", n: 250, max: 0, wantLen: 250, wantCalls: 3},
+		{name: "negative max is unlimited", n: 250, max: -1, wantLen: 250, wantCalls: 3},
+		{name: "cap mid-page", n: 250, max: 150, wantLen: 150, wantCalls: 2},
+		{name: "cap on page boundary", n: 250, max: 200, wantLen: 200, wantCalls: 2},
+		{name: "cap below page size", n: 250, max: 7, wantLen: 7, wantCalls: 1},
+		{name: "cap above total", n: 50, max: 500, wantLen: 50, wantCalls: 1},
+		{name: "empty", n: 0, max: 10, wantLen: 0, wantCalls: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fn, calls := pagedInts(test.n)
+			got, err := CollectPages(ctx, fn, test.max)
+			if err != nil {
+				t.Fatalf("CollectPages returned error: %v", err)
+			}
+			var want []int
+			for i := 1; i <= test.wantLen; i++ {
+				want = append(want, i)
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("CollectPages returned %v, want %v", got, want)
+			}
+			if *calls != test.wantCalls {
+				t.Errorf("CollectPages made %v calls, want %v", *calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCollectPages_error(t *testing.T) {
+	wantErr := errors.New("boom")
+	calls := 0
+	got, err := CollectPages(context.Background(), func(_ context.Context, opts *ListOptions) ([]int, *Response, error) {
+		calls++
+		if opts.Page == 2 {
+			return nil, nil, wantErr
+		}
+		return []int{1}, &Response{NextPage: 2}, nil
+	}, 0)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("CollectPages returned error %v, want %v", err, wantErr)
+	}
+	if got != nil {
+		t.Errorf("CollectPages returned %v along with an error, want nil", got)
+	}
+	if calls != 2 {
+		t.Errorf("CollectPages made %v calls, want 2", calls)
+	}
+}
@@ -761,7 +761,7 @@ func TestProjectsService_ListCollaborators(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Projects.ListProjectCollaborators returned %+v, want %+v", users, want)
 	}
 
@@ -802,7 +802,7 @@ func TestProjectsService_ListCollaborators_withAffiliation(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Projects.ListProjectCollaborators returned %+v, want %+v", users, want)
 	}
 }
@@ -830,7 +830,7 @@ func TestProjectsService_ReviewProjectCollaboratorPermission(t *testing.T) {
 		},
 	}
 
-	if !cmp.Equal(ppl, want) {
+	if !cmp.Equal(ppl, want, cmpUsers) {
 		t.Errorf("Projects.ReviewProjectCollaboratorPermission returned %+v, want %+v", ppl, want)
 	}
 
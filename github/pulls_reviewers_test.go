@@ -199,7 +199,7 @@ func TestListReviewers(t *testing.T) {
 			},
 		},
 	}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("PullRequests.ListReviewers returned %+v, want %+v", got, want)
 	}
 
@@ -84,7 +84,7 @@ func TestReactionsService_ListCommentReactions(t *testing.T) {
 		t.Errorf("ListCommentReactions returned error: %v", err)
 	}
 	want := []*Reaction{{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}}
-	if !cmp.Equal(reactions, want) {
+	if !cmp.Equal(reactions, want, cmpUsers) {
 		t.Errorf("ListCommentReactions = %+v, want %+v", reactions, want)
 	}
 
@@ -121,7 +121,7 @@ func TestReactionsService_CreateCommentReaction(t *testing.T) {
 		t.Errorf("CreateCommentReaction returned error: %v", err)
 	}
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("CreateCommentReaction = %+v, want %+v", got, want)
 	}
 
@@ -158,7 +158,7 @@ func TestReactionsService_ListIssueReactions(t *testing.T) {
 		t.Errorf("ListIssueReactions returned error: %v", err)
 	}
 	want := []*Reaction{{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("ListIssueReactions = %+v, want %+v", got, want)
 	}
 }
@@ -202,7 +202,7 @@ func TestReactionsService_CreateIssueReaction(t *testing.T) {
 		t.Errorf("CreateIssueReaction returned error: %v", err)
 	}
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("CreateIssueReaction = %+v, want %+v", got, want)
 	}
 
@@ -239,7 +239,7 @@ func TestReactionsService_ListIssueCommentReactions(t *testing.T) {
 		t.Errorf("ListIssueCommentReactions returned error: %v", err)
 	}
 	want := []*Reaction{{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("ListIssueCommentReactions = %+v, want %+v", got, want)
 	}
 }
@@ -283,7 +283,7 @@ func TestReactionsService_CreateIssueCommentReaction(t *testing.T) {
 		t.Errorf("CreateIssueCommentReaction returned error: %v", err)
 	}
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("CreateIssueCommentReaction = %+v, want %+v", got, want)
 	}
 
@@ -320,7 +320,7 @@ func TestReactionsService_ListPullRequestCommentReactions(t *testing.T) {
 		t.Errorf("ListPullRequestCommentReactions returned error: %v", err)
 	}
 	want := []*Reaction{{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("ListPullRequestCommentReactions = %+v, want %+v", got, want)
 	}
 }
@@ -364,7 +364,7 @@ func TestReactionsService_CreatePullRequestCommentReaction(t *testing.T) {
 		t.Errorf("CreatePullRequestCommentReaction returned error: %v", err)
 	}
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("CreatePullRequestCommentReaction = %+v, want %+v", got, want)
 	}
 
@@ -401,7 +401,7 @@ func TestReactionsService_ListTeamDiscussionReactions(t *testing.T) {
 		t.Errorf("ListTeamDiscussionReactions returned error: %v", err)
 	}
 	want := []*Reaction{{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("ListTeamDiscussionReactions = %+v, want %+v", got, want)
 	}
 }
@@ -445,7 +445,7 @@ func TestReactionsService_CreateTeamDiscussionReaction(t *testing.T) {
 		t.Errorf("CreateTeamDiscussionReaction returned error: %v", err)
 	}
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("CreateTeamDiscussionReaction = %+v, want %+v", got, want)
 	}
 
@@ -482,7 +482,7 @@ func TestReactionService_ListTeamDiscussionCommentReactions(t *testing.T) {
 		t.Errorf("ListTeamDiscussionCommentReactions returned error: %v", err)
 	}
 	want := []*Reaction{{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("ListTeamDiscussionCommentReactions = %+v, want %+v", got, want)
 	}
 }
@@ -526,7 +526,7 @@ func TestReactionService_CreateTeamDiscussionCommentReaction(t *testing.T) {
 		t.Errorf("CreateTeamDiscussionCommentReaction returned error: %v", err)
 	}
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("+1")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("CreateTeamDiscussionCommentReaction = %+v, want %+v", got, want)
 	}
 
@@ -889,7 +889,7 @@ func TestReactionService_CreateReleaseReaction(t *testing.T) {
 	}
 
 	want := &Reaction{ID: Int64(1), User: &User{Login: String("l"), ID: Int64(2)}, Content: String("rocket")}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("%v = %+v, want %+v", methodName, got, want)
 	}
 
@@ -35,7 +35,7 @@ func TestRepositoriesService_ListCollaborators(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Repositories.ListCollaborators returned %+v, want %+v", users, want)
 	}
 
@@ -54,6 +54,38 @@ func TestRepositoriesService_ListCollaborators(t *testing.T) {
 	})
 }
 
+func TestRepositoriesService_ListCollaborators_withRoleName(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"affiliation": "outside"})
+		fmt.Fprint(w, `[
+			{"id":1,"login":"a","permissions":{"pull":true,"push":true},"role_name":"write"},
+			{"id":2,"login":"b","permissions":{"pull":true},"role_name":"read"}
+		]`)
+	})
+
+	opt := &ListCollaboratorsOptions{Affiliation: "outside"}
+	ctx := context.Background()
+	users, _, err := client.Repositories.ListCollaborators(ctx, "o", "r", opt)
+	if err != nil {
+		t.Errorf("Repositories.ListCollaborators returned error: %v", err)
+	}
+
+	want := []*User{
+		{ID: Int64(1), Login: String("a"), Permissions: map[string]bool{"pull": true, "push": true}, RoleName: String("write")},
+		{ID: Int64(2), Login: String("b"), Permissions: map[string]bool{"pull": true}, RoleName: String("read")},
+	}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Repositories.ListCollaborators returned %+v, want %+v", users, want)
+	}
+	if got := users[0].GetRoleName(); got != "write" {
+		t.Errorf("GetRoleName returned %q, want %q", got, "write")
+	}
+}
+
 func TestRepositoriesService_ListCollaborators_withAffiliation(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -75,7 +107,7 @@ func TestRepositoriesService_ListCollaborators_withAffiliation(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Repositories.ListCollaborators returned %+v, want %+v", users, want)
 	}
 
@@ -115,7 +147,7 @@ func TestRepositoriesService_ListCollaborators_withPermission(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Repositories.ListCollaborators returned %+v, want %+v", users, want)
 	}
 
@@ -242,7 +274,7 @@ func TestRepositoryService_GetPermissionLevel(t *testing.T) {
 		},
 	}
 
-	if !cmp.Equal(rpl, want) {
+	if !cmp.Equal(rpl, want, cmpUsers) {
 		t.Errorf("Repositories.GetPermissionLevel returned %+v, want %+v", rpl, want)
 	}
 
@@ -299,7 +331,7 @@ func TestRepositoriesService_AddCollaborator(t *testing.T) {
 		HTMLURL:     String("https://github.com/octocat/Hello-World/invitations"),
 	}
 
-	if !cmp.Equal(collaboratorInvitation, want) {
+	if !cmp.Equal(collaboratorInvitation, want, cmpUsers) {
 		t.Errorf("AddCollaborator returned %+v, want %+v", collaboratorInvitation, want)
 	}
 
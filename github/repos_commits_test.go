@@ -141,7 +141,7 @@ func TestRepositoriesService_GetCommit(t *testing.T) {
 			},
 		},
 	}
-	if !cmp.Equal(commit, want) {
+	if !cmp.Equal(commit, want, cmpUsers) {
 		t.Errorf("Repositories.GetCommit returned \n%+v, want \n%+v", commit, want)
 	}
 
@@ -483,7 +483,7 @@ func TestRepositoriesService_CompareCommits(t *testing.T) {
 			URL:          String(fmt.Sprintf("https://api.github.com/repos/o/r/compare/%v...%v", escapedBase, escapedHead)),
 		}
 
-		if !cmp.Equal(got, want) {
+		if !cmp.Equal(got, want, cmpUsers) {
 			t.Errorf("Repositories.CompareCommits returned \n%+v, want \n%+v", got, want)
 		}
 
@@ -78,7 +78,7 @@ func TestRequiredReviewer_UnmarshalJSON(t *testing.T) {
 			if err == nil && test.wantError {
 				t.Errorf("RequiredReviewer.UnmarshalJSON returned no error when we expected one")
 			}
-			if !cmp.Equal(test.wantRule, rule) {
+			if !cmp.Equal(test.wantRule, rule, cmpUsers) {
 				t.Errorf("RequiredReviewer.UnmarshalJSON expected rule %+v, got %+v", test.wantRule, rule)
 			}
 		})
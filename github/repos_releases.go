@@ -339,6 +339,7 @@ func (s *RepositoriesService) DownloadReleaseAsset(ctx context.Context, owner, r
 	defer func() { s.client.client.CheckRedirect = saveRedirect }()
 
 	req = withContext(ctx, req)
+	s.client.setAcceptEncoding(req)
 	resp, err := s.client.client.Do(req)
 	if err != nil {
 		if !strings.Contains(err.Error(), "disable redirect") {
@@ -350,6 +351,7 @@ func (s *RepositoriesService) DownloadReleaseAsset(ctx context.Context, owner, r
 		}
 		return nil, loc, nil // Intentionally return no error with valid redirect URL.
 	}
+	decompress(resp)
 
 	if err := CheckResponse(resp); err != nil {
 		_ = resp.Body.Close()
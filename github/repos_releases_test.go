@@ -7,6 +7,7 @@ package github
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -112,7 +113,7 @@ func TestRepositoriesService_GetRelease(t *testing.T) {
 	}
 
 	want := &RepositoryRelease{ID: Int64(1), Author: &User{Login: String("l")}}
-	if !cmp.Equal(release, want) {
+	if !cmp.Equal(release, want, cmpUsers) {
 		t.Errorf("Repositories.GetRelease returned %+v, want %+v", release, want)
 	}
 
@@ -457,6 +458,36 @@ func TestRepositoriesService_DownloadReleaseAsset_Stream(t *testing.T) {
 	})
 }
 
+func TestRepositoriesService_DownloadReleaseAsset_gzip(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept-Encoding", "gzip")
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, "Hello World")
+		zw.Close()
+	})
+
+	ctx := context.Background()
+	reader, _, err := client.Repositories.DownloadReleaseAsset(ctx, "o", "r", 1, nil)
+	if err != nil {
+		t.Fatalf("Repositories.DownloadReleaseAsset returned error: %v", err)
+	}
+	defer reader.Close()
+	want := []byte("Hello World")
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Errorf("Repositories.DownloadReleaseAsset returned bad reader: %v", err)
+	}
+	if !bytes.Equal(want, content) {
+		t.Errorf("Repositories.DownloadReleaseAsset returned %q, want %q", content, want)
+	}
+}
+
 func TestRepositoriesService_DownloadReleaseAsset_Redirect(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -370,7 +370,7 @@ func TestRepositoriesService_Get(t *testing.T) {
 	}
 
 	want := &Repository{ID: Int64(1), Name: String("n"), Description: String("d"), Owner: &User{Login: String("l")}, License: &License{Key: String("mit")}, SecurityAndAnalysis: &SecurityAndAnalysis{AdvancedSecurity: &AdvancedSecurity{Status: String("enabled")}, SecretScanning: &SecretScanning{String("enabled")}, SecretScanningPushProtection: &SecretScanningPushProtection{String("enabled")}, DependabotSecurityUpdates: &DependabotSecurityUpdates{String("enabled")}}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("Repositories.Get returned %+v, want %+v", got, want)
 	}
 
@@ -454,7 +454,7 @@ func TestRepositoriesService_GetByID(t *testing.T) {
 	}
 
 	want := &Repository{ID: Int64(1), Name: String("n"), Description: String("d"), Owner: &User{Login: String("l")}, License: &License{Key: String("mit")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("Repositories.GetByID returned %+v, want %+v", got, want)
 	}
 
@@ -1202,7 +1202,7 @@ func TestRepositoriesService_GetBranchProtection(t *testing.T) {
 			Enabled: Bool(false),
 		},
 	}
-	if !cmp.Equal(protection, want) {
+	if !cmp.Equal(protection, want, cmpUsers) {
 		t.Errorf("Repositories.GetBranchProtection returned %+v, want %+v", protection, want)
 	}
 
@@ -1291,7 +1291,7 @@ func TestRepositoriesService_GetBranchProtection_noDismissalRestrictions(t *test
 			},
 		},
 	}
-	if !cmp.Equal(protection, want) {
+	if !cmp.Equal(protection, want, cmpUsers) {
 		t.Errorf("Repositories.GetBranchProtection returned %+v, want %+v", protection, want)
 	}
 }
@@ -1479,7 +1479,7 @@ func TestRepositoriesService_UpdateBranchProtection_Contexts(t *testing.T) {
 			Enabled: Bool(true),
 		},
 	}
-	if !cmp.Equal(protection, want) {
+	if !cmp.Equal(protection, want, cmpUsers) {
 		t.Errorf("Repositories.UpdateBranchProtection returned %+v, want %+v", protection, want)
 	}
 
@@ -1638,7 +1638,7 @@ func TestRepositoriesService_UpdateBranchProtection_Checks(t *testing.T) {
 			},
 		},
 	}
-	if !cmp.Equal(protection, want) {
+	if !cmp.Equal(protection, want, cmpUsers) {
 		t.Errorf("Repositories.UpdateBranchProtection returned %+v, want %+v", protection, want)
 	}
 }
@@ -1771,7 +1771,7 @@ func TestRepositoriesService_UpdateBranchProtection_StrictNoChecks(t *testing.T)
 			},
 		},
 	}
-	if !cmp.Equal(protection, want) {
+	if !cmp.Equal(protection, want, cmpUsers) {
 		t.Errorf("Repositories.UpdateBranchProtection returned %+v, want %+v", protection, want)
 	}
 }
@@ -2265,7 +2265,7 @@ func TestRepositoriesService_GetPullRequestReviewEnforcement(t *testing.T) {
 		RequiredApprovingReviewCount: 1,
 	}
 
-	if !cmp.Equal(enforcement, want) {
+	if !cmp.Equal(enforcement, want, cmpUsers) {
 		t.Errorf("Repositories.GetPullRequestReviewEnforcement returned %+v, want %+v", enforcement, want)
 	}
 
@@ -2340,7 +2340,7 @@ func TestRepositoriesService_UpdatePullRequestReviewEnforcement(t *testing.T) {
 		RequireCodeOwnerReviews:      true,
 		RequiredApprovingReviewCount: 3,
 	}
-	if !cmp.Equal(enforcement, want) {
+	if !cmp.Equal(enforcement, want, cmpUsers) {
 		t.Errorf("Repositories.UpdatePullRequestReviewEnforcement returned %+v, want %+v", enforcement, want)
 	}
 
@@ -3151,7 +3151,7 @@ func TestRepositoriesService_ReplaceUserRestrictions(t *testing.T) {
 	want := []*User{
 		{Name: String("octocat")},
 	}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("Repositories.ReplaceUserRestrictions returned %+v, want %+v", got, want)
 	}
 
@@ -3189,7 +3189,7 @@ func TestRepositoriesService_AddUserRestrictions(t *testing.T) {
 	want := []*User{
 		{Name: String("octocat")},
 	}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("Repositories.AddUserRestrictions returned %+v, want %+v", got, want)
 	}
 
@@ -3267,7 +3267,7 @@ func TestRepositoriesService_Transfer(t *testing.T) {
 	}
 
 	want := &Repository{Owner: &User{Login: String("a")}}
-	if !cmp.Equal(got, want) {
+	if !cmp.Equal(got, want, cmpUsers) {
 		t.Errorf("Repositories.Transfer returned %+v, want %+v", got, want)
 	}
 
@@ -3344,7 +3344,7 @@ func TestRepositoriesService_Dispatch(t *testing.T) {
 		}
 
 		want := &Repository{Owner: &User{Login: String("a")}}
-		if !cmp.Equal(got, want) {
+		if !cmp.Equal(got, want, cmpUsers) {
 			t.Errorf("Repositories.Dispatch returned %+v, want %+v", got, want)
 		}
 	}
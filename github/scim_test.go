@@ -127,6 +127,7 @@ func TestSCIMService_ProvisionAndInviteSCIMUser(t *testing.T) {
 
 	mux.HandleFunc("/scim/v2/organizations/o/Users", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
+		testBody(t, r, `{"userName":"userName","name":{"givenName":"givenName","familyName":"familyName"},"emails":[{"value":"octocat@github.com"}]}`+"\n")
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -258,6 +259,7 @@ func TestSCIMService_UpdateProvisionedOrgMembership(t *testing.T) {
 
 	mux.HandleFunc("/scim/v2/organizations/o/Users/123", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
+		testBody(t, r, `{"userName":"userName","name":{"givenName":"givenName","familyName":"familyName"},"emails":[{"value":"octocat@github.com"}]}`+"\n")
 		w.WriteHeader(http.StatusOK)
 	})
 
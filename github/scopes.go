@@ -0,0 +1,85 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// impliedScopes maps OAuth scopes to the narrower scopes they include.
+// See https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps#available-scopes
+var impliedScopes = map[string][]string{
+	"repo":                      {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:repo_hook":           {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":           {"read:repo_hook"},
+	"admin:org":                 {"write:org", "read:org"},
+	"write:org":                 {"read:org"},
+	"admin:public_key":          {"write:public_key", "read:public_key"},
+	"write:public_key":          {"read:public_key"},
+	"user":                      {"read:user", "user:email", "user:follow"},
+	"write:packages":            {"read:packages"},
+	"admin:gpg_key":             {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":             {"read:gpg_key"},
+	"admin:enterprise":          {"manage_runners:enterprise", "manage_billing:enterprise", "read:enterprise"},
+	"manage_billing:enterprise": {"read:enterprise"},
+	"project":                   {"read:project"},
+}
+
+// MissingScopesError occurs when the OAuth token used by the client lacks
+// scopes required by the caller, as reported by Client.CheckScopes.
+type MissingScopesError struct {
+	Missing     []string // required scopes the token does not have
+	TokenScopes []string // scopes granted to the token
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("token is missing required OAuth scopes: %v", strings.Join(e.Missing, ", "))
+}
+
+// CheckScopes makes a request for the authenticated user to determine the
+// OAuth scopes granted to the client's token, and returns a *MissingScopesError
+// if any of the required scopes is not granted, either directly or as part of
+// a broader scope such as "repo". This lets callers fail fast, before making
+// calls that would be rejected.
+//
+// Tokens that do not report their scopes, such as fine-grained personal access
+// tokens and GitHub App installation tokens, cannot be checked, and CheckScopes
+// returns nil for them.
+func (c *Client) CheckScopes(ctx context.Context, required ...string) error {
+	req, err := c.NewRequest("GET", "user", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	if resp.TokenScopes == nil {
+		return nil
+	}
+
+	granted := make(map[string]bool)
+	for _, scope := range resp.TokenScopes {
+		granted[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			granted[implied] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingScopesError{Missing: missing, TokenScopes: resp.TokenScopes}
+	}
+	return nil
+}
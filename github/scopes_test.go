@@ -0,0 +1,90 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_CheckScopes(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		tokenScopes string
+		required    []string
+		wantMissing []string
+	}{
+		{name: "no scopes required", tokenScopes: "repo"},
+		{name: "granted", tokenScopes: "repo, read:org", required: []string{"read:org", "repo"}},
+		{name: "implied", tokenScopes: "repo, admin:org", required: []string{"public_repo", "read:org"}},
+		{name: "missing", tokenScopes: "read:org, user:email", required: []string{"repo", "read:org", "user"}, wantMissing: []string{"repo", "user"}},
+		{name: "no scopes granted", tokenScopes: "", required: []string{"repo"}, wantMissing: []string{"repo"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				w.Header().Set(headerOAuthScopes, test.tokenScopes)
+				fmt.Fprint(w, `{"id":1}`)
+			})
+
+			err := client.CheckScopes(context.Background(), test.required...)
+			if test.wantMissing == nil {
+				assertNilError(t, err)
+				return
+			}
+
+			var scopesErr *MissingScopesError
+			if !errors.As(err, &scopesErr) {
+				t.Fatalf("CheckScopes returned error %v, want *MissingScopesError", err)
+			}
+			if !cmp.Equal(scopesErr.Missing, test.wantMissing) {
+				t.Errorf("MissingScopesError.Missing = %v, want %v", scopesErr.Missing, test.wantMissing)
+			}
+		})
+	}
+}
+
+func TestClient_CheckScopes_unreported(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	err := client.CheckScopes(context.Background(), "repo")
+	assertNilError(t, err)
+}
+
+func TestClient_CheckScopes_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	})
+
+	err := client.CheckScopes(context.Background(), "repo")
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("CheckScopes returned error %v, want *ErrorResponse", err)
+	}
+}
+
+func TestMissingScopesError_Error(t *testing.T) {
+	err := &MissingScopesError{Missing: []string{"repo", "user"}}
+	if got, want := err.Error(), "token is missing required OAuth scopes: repo, user"; got != want {
+		t.Errorf("MissingScopesError.Error() = %q, want %q", got, want)
+	}
+}
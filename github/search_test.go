@@ -384,7 +384,7 @@ func TestSearchService_Users(t *testing.T) {
 		IncompleteResults: Bool(false),
 		Users:             []*User{{ID: Int64(1)}, {ID: Int64(2)}},
 	}
-	if !cmp.Equal(result, want) {
+	if !cmp.Equal(result, want, cmpUsers) {
 		t.Errorf("Search.Users returned %+v, want %+v", result, want)
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 var timestampType = reflect.TypeOf(Timestamp{})
@@ -19,13 +20,42 @@ var timestampType = reflect.TypeOf(Timestamp{})
 func Stringify(message interface{}) string {
 	var buf bytes.Buffer
 	v := reflect.ValueOf(message)
-	stringifyValue(&buf, v)
+	stringifyValue(&buf, v, false)
 	return buf.String()
 }
 
+// StringifyCompact is like Stringify, but also omits struct fields holding
+// zero values, such as empty strings, zeros, false, empty slices and maps, and
+// pointers to any of these. It produces terser output for sparsely populated
+// values, such as in logs, at the cost of not telling a field set to its zero
+// value from one that is unset.
+func StringifyCompact(message interface{}) string {
+	var buf bytes.Buffer
+	v := reflect.ValueOf(message)
+	stringifyValue(&buf, v, true)
+	return buf.String()
+}
+
+// isEmptyValue reports whether v is a nil pointer, or holds the zero value or
+// an empty slice or map, after following pointers.
+func isEmptyValue(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
 // stringifyValue was heavily inspired by the goprotobuf library.
+// If compact is true, struct fields holding empty values are omitted.
 
-func stringifyValue(w *bytes.Buffer, val reflect.Value) {
+func stringifyValue(w *bytes.Buffer, val reflect.Value, compact bool) {
 	if val.Kind() == reflect.Ptr && val.IsNil() {
 		w.Write([]byte("<nil>"))
 		return
@@ -38,12 +68,43 @@ func stringifyValue(w *bytes.Buffer, val reflect.Value) {
 		fmt.Fprintf(w, `"%s"`, v)
 	case reflect.Slice:
 		w.Write([]byte{'['})
+		var sep bool
 		for i := 0; i < v.Len(); i++ {
+			ev := v.Index(i)
+			// skip nil elements, as is done for struct fields
+			if ev.Kind() == reflect.Ptr && ev.IsNil() {
+				continue
+			}
+
+			if sep {
+				w.Write([]byte{' '})
+			} else {
+				sep = true
+			}
+
+			stringifyValue(w, ev, compact)
+		}
+
+		w.Write([]byte{']'})
+		return
+	case reflect.Map:
+		// sort map keys so that output is deterministic
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Sort(mapKeys{keys, names})
+
+		w.Write([]byte("map["))
+		for i, k := range keys {
 			if i > 0 {
 				w.Write([]byte{' '})
 			}
 
-			stringifyValue(w, v.Index(i))
+			w.WriteString(names[i])
+			w.Write([]byte{':'})
+			stringifyValue(w, v.MapIndex(k), compact)
 		}
 
 		w.Write([]byte{']'})
@@ -64,6 +125,9 @@ func stringifyValue(w *bytes.Buffer, val reflect.Value) {
 		var sep bool
 		for i := 0; i < v.NumField(); i++ {
 			fv := v.Field(i)
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
 			if fv.Kind() == reflect.Ptr && fv.IsNil() {
 				continue
 			}
@@ -73,6 +137,9 @@ func stringifyValue(w *bytes.Buffer, val reflect.Value) {
 			if fv.Kind() == reflect.Map && fv.IsNil() {
 				continue
 			}
+			if compact && isEmptyValue(fv) {
+				continue
+			}
 
 			if sep {
 				w.Write([]byte(", "))
@@ -82,7 +149,7 @@ func stringifyValue(w *bytes.Buffer, val reflect.Value) {
 
 			w.Write([]byte(v.Type().Field(i).Name))
 			w.Write([]byte{':'})
-			stringifyValue(w, fv)
+			stringifyValue(w, fv, compact)
 		}
 
 		w.Write([]byte{'}'})
@@ -92,3 +159,16 @@ func stringifyValue(w *bytes.Buffer, val reflect.Value) {
 		}
 	}
 }
+
+// mapKeys sorts map keys by their printed form.
+type mapKeys struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (m mapKeys) Len() int           { return len(m.keys) }
+func (m mapKeys) Less(i, j int) bool { return m.names[i] < m.names[j] }
+func (m mapKeys) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.names[i], m.names[j] = m.names[j], m.names[i]
+}
@@ -42,6 +42,23 @@ func TestStringify(t *testing.T) {
 			`{A:"foo"}`,
 		},
 
+		// maps
+		{
+			map[string]bool{"push": true, "admin": true, "pull": false},
+			`map[admin:true pull:false push:true]`,
+		},
+		{
+			map[string]*string{"b": String("y"), "a": String("x")},
+			`map[a:"x" b:"y"]`,
+		},
+		{
+			struct {
+				A map[string]bool
+			}{nil},
+			// nil map is skipped
+			`{}`,
+		},
+
 		// pointers
 		{nilPointer, `<nil>`},
 		{String("foo"), `"foo"`},
@@ -51,6 +68,11 @@ func TestStringify(t *testing.T) {
 			[]*string{String("a"), String("b")},
 			`["a" "b"]`,
 		},
+		{
+			[]*string{nil, String("a"), nil, String("b"), nil},
+			// nil elements are skipped
+			`["a" "b"]`,
+		},
 
 		// actual GitHub structs
 		{
@@ -65,6 +87,21 @@ func TestStringify(t *testing.T) {
 			User{ID: Int64(123), Name: String("n")},
 			`github.User{ID:123, Name:"n"}`,
 		},
+		{
+			User{ID: Int64(123), Permissions: map[string]bool{"push": true, "admin": true}},
+			`github.User{ID:123, Permissions:map[admin:true push:true]}`,
+		},
+		{
+			User{
+				Login: String("u"),
+				TextMatches: []*TextMatch{
+					{Property: String("login"), Fragment: String("u"), Matches: []*Match{{Text: String("u"), Indices: []int{0, 1}}}},
+					nil,
+					{Property: String("name"), Fragment: String("n")},
+				},
+			},
+			`github.User{Login:"u", TextMatches:[github.TextMatch{Property:"login", Fragment:"u", Matches:[github.Match{Text:"u", Indices:[0 1]}]} github.TextMatch{Property:"name", Fragment:"n"}]}`,
+		},
 		{
 			Repository{Owner: &User{ID: Int64(123)}},
 			`github.Repository{Owner:github.User{ID:123}}`,
@@ -79,6 +116,57 @@ func TestStringify(t *testing.T) {
 	}
 }
 
+func TestStringify_mapIsStable(t *testing.T) {
+	m := map[string]bool{"a": true, "b": false, "c": true, "d": false, "e": true}
+	want := Stringify(m)
+	for i := 0; i < 20; i++ {
+		if got := Stringify(m); got != want {
+			t.Fatalf("Stringify(%v) = %q, want stable output %q", m, got, want)
+		}
+	}
+}
+
+func TestStringifyCompact(t *testing.T) {
+	user := &User{
+		Login:       String("u"),
+		ID:          Int64(1),
+		Name:        String(""),
+		Hireable:    Bool(false),
+		Followers:   Int(0),
+		Plan:        &Plan{Name: String("free"), Space: Int(0)},
+		Permissions: map[string]bool{},
+		TextMatches: []*TextMatch{},
+	}
+
+	wantVerbose := `github.User{Login:"u", ID:1, Name:"", Hireable:false, Followers:0, ` +
+		`Plan:github.Plan{Name:"free", Space:0}, TextMatches:[], Permissions:map[]}`
+	if got := Stringify(user); got != wantVerbose {
+		t.Errorf("Stringify(user) = %q, want %q", got, wantVerbose)
+	}
+	wantCompact := `github.User{Login:"u", ID:1, Plan:github.Plan{Name:"free"}}`
+	if got := StringifyCompact(user); got != wantCompact {
+		t.Errorf("StringifyCompact(user) = %q, want %q", got, wantCompact)
+	}
+
+	var tests = []struct {
+		in  interface{}
+		out string
+	}{
+		{(*User)(nil), `<nil>`},
+		{User{}, `github.User{}`},
+		{User{Plan: &Plan{}}, `github.User{}`},
+		{"", `""`},
+		{0, `0`},
+		{[]int{0, 1}, `[0 1]`},
+		{Timestamp{referenceTime}, `github.Timestamp{2006-01-02 15:04:05 +0000 UTC}`},
+	}
+	for i, tt := range tests {
+		if s := StringifyCompact(tt.in); s != tt.out {
+			t.Errorf("%d. StringifyCompact(%q) => %q, want %q", i, tt.in, s, tt.out)
+		}
+	}
+}
+
 // Directly test the String() methods on various GitHub types. We don't do an
 // exaustive test of all the various field types, since TestStringify() above
 // takes care of that. Rather, we just make sure that Stringify() is being
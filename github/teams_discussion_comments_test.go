@@ -125,7 +125,7 @@ func TestTeamsService_ListComments(t *testing.T) {
 		t.Errorf("Teams.ListCommentsByID returned error: %v", err)
 	}
 
-	if !cmp.Equal(commentsByID, want) {
+	if !cmp.Equal(commentsByID, want, cmpUsers) {
 		t.Errorf("Teams.ListCommentsByID returned %+v, want %+v", commentsByID, want)
 	}
 
@@ -138,7 +138,7 @@ func TestTeamsService_ListComments(t *testing.T) {
 		t.Errorf("Teams.ListCommentsBySlug returned error: %v", err)
 	}
 
-	if !cmp.Equal(commentsBySlug, want) {
+	if !cmp.Equal(commentsBySlug, want, cmpUsers) {
 		t.Errorf("Teams.ListCommentsBySlug returned %+v, want %+v", commentsBySlug, want)
 	}
 
@@ -112,7 +112,7 @@ func TestTeamsService_ListDiscussionsByID(t *testing.T) {
 			URL:           String("https://api.github.com/teams/2/discussions/3"),
 		},
 	}
-	if !cmp.Equal(discussions, want) {
+	if !cmp.Equal(discussions, want, cmpUsers) {
 		t.Errorf("Teams.ListDiscussionsByID returned %+v, want %+v", discussions, want)
 	}
 
@@ -227,7 +227,7 @@ func TestTeamsService_ListDiscussionsBySlug(t *testing.T) {
 			URL:           String("https://api.github.com/teams/2/discussions/3"),
 		},
 	}
-	if !cmp.Equal(discussions, want) {
+	if !cmp.Equal(discussions, want, cmpUsers) {
 		t.Errorf("Teams.ListDiscussionsBySlug returned %+v, want %+v", discussions, want)
 	}
 
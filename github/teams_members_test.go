@@ -33,7 +33,7 @@ func TestTeamsService__ListTeamMembersByID(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(members, want) {
+	if !cmp.Equal(members, want, cmpUsers) {
 		t.Errorf("Teams.ListTeamMembersByID returned %+v, want %+v", members, want)
 	}
 
@@ -108,7 +108,7 @@ func TestTeamsService__ListTeamMembersBySlug(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(members, want) {
+	if !cmp.Equal(members, want, cmpUsers) {
 		t.Errorf("Teams.ListTeamMembersBySlug returned %+v, want %+v", members, want)
 	}
 
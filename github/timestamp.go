@@ -6,6 +6,7 @@
 package github
 
 import (
+	"net/url"
 	"strconv"
 	"time"
 )
@@ -46,6 +47,13 @@ func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
 	return
 }
 
+// EncodeValues implements the query.Encoder interface, so that Timestamp
+// fields of option structs are encoded as RFC3339 query parameters.
+func (t Timestamp) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, t.Format(time.RFC3339))
+	return nil
+}
+
 // Equal reports whether t and u are equal based on time.Equal
 func (t Timestamp) Equal(u Timestamp) bool {
 	return t.Time.Equal(u.Time)
@@ -0,0 +1,59 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"net/http"
+)
+
+// TracingTransport is an http.RoundTripper that propagates the trace context
+// carried by the context of each request, such as by setting the traceparent
+// and tracestate headers, so that API calls show up in distributed traces.
+//
+// The headers are written by Propagator, which keeps this package free of a
+// dependency on any tracing library. With OpenTelemetry, for example:
+//
+//	propagator := func(ctx context.Context, h http.Header) {
+//		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+//	}
+type TracingTransport struct {
+	// Transport is the underlying transport used to make requests.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Propagator writes the trace context of ctx into the headers of an
+	// outgoing request. If nil, requests are sent unchanged.
+	Propagator func(ctx context.Context, h http.Header)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if t.Propagator == nil {
+		return transport.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	t.Propagator(req.Context(), req.Header)
+	return transport.RoundTrip(req)
+}
+
+// WithPropagator returns a copy of the client whose requests go through a
+// TracingTransport using propagator, layered on top of the transport of the
+// client. The original client is left untouched.
+func (c *Client) WithPropagator(propagator func(ctx context.Context, h http.Header)) *Client {
+	c2 := c.copy()
+	defer c2.initialize()
+	c2.client.Transport = &TracingTransport{
+		Transport:  c2.client.Transport,
+		Propagator: propagator,
+	}
+	return c2
+}
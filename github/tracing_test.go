@@ -0,0 +1,60 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type traceKey struct{}
+
+// fakePropagator sets the trace headers from the trace ID stored in ctx under
+// traceKey, if any.
+func fakePropagator(ctx context.Context, h http.Header) {
+	if id, ok := ctx.Value(traceKey{}).(string); ok {
+		h.Set("traceparent", "00-"+id+"-00f067aa0ba902b7-01")
+		h.Set("tracestate", "vendor=value")
+	}
+}
+
+func TestClient_WithPropagator(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	mux.HandleFunc("/traced", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		testHeader(t, r, "tracestate", "vendor=value")
+	})
+	mux.HandleFunc("/untraced", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "traceparent", "")
+		testHeader(t, r, "tracestate", "")
+	})
+
+	traced := client.WithPropagator(fakePropagator)
+
+	ctx := context.WithValue(context.Background(), traceKey{}, traceID)
+	req, _ := traced.NewRequest("GET", "traced", nil)
+	if _, err := traced.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got := req.Header.Get("traceparent"); got != "" {
+		t.Errorf("Do modified the request: traceparent = %q", got)
+	}
+
+	// Requests without a trace context, or from the original client, are
+	// left alone.
+	req, _ = traced.NewRequest("GET", "untraced", nil)
+	if _, err := traced.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	req, _ = client.NewRequest("GET", "untraced", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+}
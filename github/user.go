@@ -8,34 +8,47 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sync"
 )
 
 // SelfService handles communication with the
 // methods of the GitHub API which handle the currently logged in user
 //
+// The embedded Self caches the user as last fetched. Use CurrentUser rather
+// than accessing it directly when the service is used concurrently.
+//
 // GitHub API docs: https://docs.github.com/en/rest/user/
 type SelfService struct {
 	Self
 	service
+
+	mu sync.RWMutex // guards Self
 }
 
-// Self is a [User] with some extra properties.
-// It refers to the user making the API call.
+// Self is the [User] making the API call. The private properties of the
+// authenticated user, such as Plan, DiskUsage or TwoFactorAuthentication,
+// are populated on the embedded User.
 type Self struct {
 	User
-	Collaborators           int
-	DiskUsage               int
-	Plan                    Plan
-	OwnedPrivateRepos       int
-	TotalPrivateRepos       int
-	PrivateGists            int
-	TwoFactorAuthentication bool
 }
 
 func (u Self) String() string {
 	return Stringify(u)
 }
 
+// CurrentUser returns a copy of the cached authenticated user, as last
+// fetched by Get, Refresh or Edit. It is safe to call concurrently with them.
+func (s *SelfService) CurrentUser() Self {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Self
+}
+
+func (s *SelfService) String() string {
+	return s.CurrentUser().String()
+}
+
 // Get fetches the currently logged-in user.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/users#get-the-authenticated-user
@@ -50,17 +63,36 @@ func (s *SelfService) Get(ctx context.Context) (*Self, *Response, error) {
 	if err != nil {
 		return nil, resp, err
 	}
+	if resp.StatusCode == http.StatusNoContent {
+		// The response, such as that of a dry run, holds no user to cache.
+		return nil, resp, nil
+	}
 
+	s.mu.Lock()
 	s.Self = *uResp
+	s.mu.Unlock()
 
 	return uResp, resp, nil
 }
 
-// Edit the authenticated user.
+// Refresh fetches the currently logged-in user again, replacing the cached
+// Self. It is a convenience for reload loops that only care about failure.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-the-authenticated-user
+func (s *SelfService) Refresh(ctx context.Context) error {
+	_, _, err := s.Get(ctx)
+	return err
+}
+
+// Edit the authenticated user, changing the profile fields set in user. To
+// avoid overwriting concurrent changes, pass WithIfMatch with the ETag of the
+// response the profile was read from; the edit then fails with a
+// *PreconditionFailedError if the profile has changed. With Client.DryRun,
+// Edit returns a nil *Self and leaves the cached user as it was.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/users#update-the-authenticated-user
-func (s *SelfService) Edit(ctx context.Context) (*Self, *Response, error) {
-	req, err := s.client.NewRequest("PATCH", "/user", nil)
+func (s *SelfService) Edit(ctx context.Context, user *User, opts ...RequestOption) (*Self, *Response, error) {
+	req, err := s.client.NewRequest("PATCH", "/user", user, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,8 +102,14 @@ func (s *SelfService) Edit(ctx context.Context) (*Self, *Response, error) {
 	if err != nil {
 		return nil, resp, err
 	}
+	if resp.StatusCode == http.StatusNoContent {
+		// The response, such as that of a dry run, holds no user to cache.
+		return nil, resp, nil
+	}
 
+	s.mu.Lock()
 	s.Self = *uResp
+	s.mu.Unlock()
 
 	return uResp, resp, nil
 }
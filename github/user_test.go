@@ -0,0 +1,228 @@
+// Copyright 2013 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSelfService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	ctx := context.Background()
+	self, _, err := client.Self.Get(ctx)
+	if err != nil {
+		t.Errorf("Self.Get returned error: %v", err)
+	}
+
+	want := &Self{User: User{ID: Int64(1)}}
+	if !cmp.Equal(self, want, cmpUsers) {
+		t.Errorf("Self.Get returned %+v, want %+v", self, want)
+	}
+
+	const methodName = "Get"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Self.Get(ctx)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestSelfService_Refresh(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	logins := []string{"before", "after"}
+	var calls int
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"id":1,"login":%q}`, logins[calls])
+		calls++
+	})
+
+	ctx := context.Background()
+	if err := client.Self.Refresh(ctx); err != nil {
+		t.Fatalf("Self.Refresh returned error: %v", err)
+	}
+	if got, want := client.Self.GetLogin(), "before"; got != want {
+		t.Errorf("Self login after first Refresh = %q, want %q", got, want)
+	}
+
+	if err := client.Self.Refresh(ctx); err != nil {
+		t.Fatalf("Self.Refresh returned error: %v", err)
+	}
+	want := Self{User: User{ID: Int64(1), Login: String("after")}}
+	if !cmp.Equal(client.Self.Self, want, cmpUsers) {
+		t.Errorf("Self after second Refresh = %+v, want %+v", client.Self.Self, want)
+	}
+
+	// A failed Refresh leaves the cached Self untouched.
+	client.BaseURL.Path = ""
+	if err := client.Self.Refresh(ctx); err == nil {
+		t.Error("Self.Refresh returned nil error, want error")
+	}
+	if !cmp.Equal(client.Self.Self, want, cmpUsers) {
+		t.Errorf("Self after failed Refresh = %+v, want %+v", client.Self.Self, want)
+	}
+}
+
+func TestSelfService_CurrentUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"login":"l"}`)
+	})
+
+	if got, want := client.Self.CurrentUser(), (Self{}); !cmp.Equal(got, want, cmpUsers) {
+		t.Errorf("Self.CurrentUser before Get = %+v, want %+v", got, want)
+	}
+
+	// Run with -race to detect unsynchronized access to the cached Self.
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Self.Get(ctx); err != nil {
+				t.Errorf("Self.Get returned error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if got := client.Self.CurrentUser(); got.ID != nil && got.GetLogin() != "l" {
+				t.Errorf("Self.CurrentUser returned %+v", got)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = client.Self.String()
+		}()
+	}
+	wg.Wait()
+
+	want := Self{User: User{ID: Int64(1), Login: String("l")}}
+	if got := client.Self.CurrentUser(); !cmp.Equal(got, want, cmpUsers) {
+		t.Errorf("Self.CurrentUser = %+v, want %+v", got, want)
+	}
+}
+
+func TestSelfService_Edit_ifMatch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	etag := `"v1"`
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			testBody(t, r, `{"name":"n"}`+"\n")
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				fmt.Fprint(w, `{"message":"Precondition Failed"}`)
+				return
+			}
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, `{"id":1,"login":"l"}`)
+	})
+
+	ctx := context.Background()
+	_, resp, err := client.Self.Get(ctx)
+	if err != nil {
+		t.Fatalf("Self.Get returned error: %v", err)
+	}
+	read := resp.Header.Get("ETag")
+
+	// Another process edits the profile.
+	etag = `"v2"`
+
+	input := &User{Name: String("n")}
+	_, _, err = client.Self.Edit(ctx, input, WithIfMatch(read))
+	var preconditionErr *PreconditionFailedError
+	if !errors.As(err, &preconditionErr) {
+		t.Fatalf("Self.Edit returned error %#v, want *PreconditionFailedError", err)
+	}
+	if got, want := preconditionErr.Message, "Precondition Failed"; got != want {
+		t.Errorf("PreconditionFailedError.Message = %q, want %q", got, want)
+	}
+
+	if _, _, err := client.Self.Edit(ctx, input, WithIfMatch(etag)); err != nil {
+		t.Errorf("Self.Edit with current ETag returned error: %v", err)
+	}
+}
+
+func TestSelfService_Edit_DryRun(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":1,"login":"l"}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Self.Get(ctx); err != nil {
+		t.Fatalf("Self.Get returned error: %v", err)
+	}
+
+	client.DryRun = true
+	self, _, err := client.Self.Edit(ctx, &User{Name: String("n")})
+	if err != nil {
+		t.Fatalf("Self.Edit returned error: %v", err)
+	}
+	if self != nil {
+		t.Errorf("Self.Edit returned %+v in dry-run mode, want nil", self)
+	}
+	if current := client.Self.CurrentUser(); current.GetLogin() != "l" {
+		t.Errorf("Self.CurrentUser login = %q after a dry-run edit, want %q", current.GetLogin(), "l")
+	}
+}
+
+func TestSelf_Marshal(t *testing.T) {
+	testJSONMarshal(t, &Self{}, "{}")
+
+	u := &Self{
+		User: User{
+			Login:                   String("l"),
+			ID:                      Int64(1),
+			DiskUsage:               Int(1),
+			Collaborators:           Int(2),
+			OwnedPrivateRepos:       Int64(3),
+			TotalPrivateRepos:       Int64(4),
+			PrivateGists:            Int(5),
+			TwoFactorAuthentication: Bool(true),
+			Plan:                    &Plan{Name: String("pro")},
+		},
+	}
+	want := `{
+		"login": "l",
+		"id": 1,
+		"disk_usage": 1,
+		"collaborators": 2,
+		"owned_private_repos": 3,
+		"total_private_repos": 4,
+		"private_gists": 5,
+		"two_factor_authentication": true,
+		"plan": {"name": "pro"}
+	}`
+	testJSONMarshal(t, u, want)
+	assertRoundTrip(t, u)
+}
@@ -7,7 +7,17 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // UsersService handles communication with the user related
@@ -49,6 +59,7 @@ type User struct {
 	TwoFactorAuthentication *bool      `json:"two_factor_authentication,omitempty"`
 	Plan                    *Plan      `json:"plan,omitempty"`
 	LdapDn                  *string    `json:"ldap_dn,omitempty"`
+	Spammy                  *bool      `json:"spammy,omitempty"`
 
 	// API URLs
 	URL               *string `json:"url,omitempty"`
@@ -70,35 +81,186 @@ type User struct {
 	// repository. These are only populated when calling Repositories.ListCollaborators.
 	Permissions map[string]bool `json:"permissions,omitempty"`
 	RoleName    *string         `json:"role_name,omitempty"`
+
+	// rawJSON holds the fields of the decoded JSON object that User does not
+	// model, if the user was fetched with the WithRawJSON option. See Raw.
+	rawJSON json.RawMessage
 }
 
 func (u User) String() string {
 	return Stringify(u)
 }
 
+// userFields is the set of JSON field names modeled by User.
+var userFields = func() map[string]bool {
+	t := reflect.TypeOf(User{})
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// setRawJSON sets u.rawJSON to the fields of the JSON object data that are
+// not modeled by User, or to nil if there are none.
+func (u *User) setRawJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name := range fields {
+		if userFields[name] {
+			delete(fields, name)
+		}
+	}
+	u.rawJSON = nil
+	if len(fields) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	u.rawJSON = raw
+	return nil
+}
+
+// Raw returns the fields of the JSON object u was decoded from that are not
+// modeled by User, as a JSON object, so that they can be recovered if the API
+// adds new fields. They are only collected for users fetched with the
+// WithRawJSON option. It returns nil if there were none.
+func (u *User) Raw() json.RawMessage {
+	if u == nil {
+		return nil
+	}
+	return u.rawJSON
+}
+
+// HasPaidPlan reports whether the user is on a plan other than "free". It
+// returns false if the plan is unknown, which is the case for users other than
+// the authenticated user.
+func (u *User) HasPaidPlan() bool {
+	name := u.GetPlan().GetName()
+	return name != "" && name != "free"
+}
+
+// IsSpammy reports whether the user has been flagged as spammy. The flag is only
+// reported to site administrators on GitHub Enterprise, so it is false otherwise.
+func (u *User) IsSpammy() bool {
+	return u.GetSpammy()
+}
+
+// IsSuspended reports whether the user account is suspended. SuspendedAt is
+// only reported on GitHub Enterprise, so it is false otherwise.
+func (u *User) IsSuspended() bool {
+	return u != nil && u.SuspendedAt != nil
+}
+
 // Get fetches a user. Passing the empty string will fetch the authenticated
-// user.
+// user. Request options such as WithIfModifiedSince may be passed to make a
+// conditional request.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/users#get-a-user
-func (s *UsersService) Get(ctx context.Context, user string) (*User, *Response, error) {
+func (s *UsersService) Get(ctx context.Context, user string, opts ...RequestOption) (*User, *Response, error) {
 	var u string
 	if user != "" {
 		u = fmt.Sprintf("users/%v", user)
 	} else {
 		u = "user"
 	}
-	req, err := s.client.NewRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
+
+	return do[User](ctx, s.client, "GET", u, nil, nil, opts...)
+}
+
+// GetMany fetches the users with the given logins, using at most concurrency
+// concurrent requests. The fetched users are returned keyed by login. If some
+// of them could not be fetched, the others are returned along with a
+// *MultiError holding the error for each failed login. If ctx is canceled,
+// no further requests are made, and the users fetched so far are returned
+// along with ctx.Err().
+func (s *UsersService) GetMany(ctx context.Context, logins []string, concurrency int) (map[string]*User, error) {
+	var (
+		mu    sync.Mutex
+		users = make(map[string]*User, len(logins))
+		errs  = make(map[string]error)
+	)
+
+	forEachConcurrently(ctx, distinct(logins), concurrency, func(login string) {
+		user, _, err := s.Get(ctx, login)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[login] = err
+		} else {
+			users[login] = user
+		}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return users, err
+	}
+	if len(errs) > 0 {
+		return users, &MultiError{Errors: errs}
 	}
+	return users, nil
+}
 
-	uResp := new(User)
-	resp, err := s.client.Do(ctx, req, uResp)
-	if err != nil {
-		return nil, resp, err
+// forEachConcurrently calls fn for each of items, with at most concurrency
+// calls in flight, and waits for them to return. Once ctx is done, no further
+// calls are started, and the items that were not passed to fn are returned.
+func forEachConcurrently[T any](ctx context.Context, items []T, concurrency int, fn func(T)) (unstarted []T) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan T)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(items); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				fn(item)
+			}
+		}()
+	}
+
+dispatch:
+	for i, item := range items {
+		// Check ctx first, since select picks at random when a worker
+		// is also ready.
+		if ctx.Err() != nil {
+			unstarted = items[i:]
+			break
+		}
+		select {
+		case jobs <- item:
+		case <-ctx.Done():
+			unstarted = items[i:]
+			break dispatch
+		}
 	}
+	close(jobs)
+	wg.Wait()
+
+	return unstarted
+}
 
-	return uResp, resp, nil
+// distinct returns the strings in ss without duplicates, keeping the first
+// occurrence of each.
+func distinct(ss []string) []string {
+	seen := make(map[string]bool, len(ss))
+	var out []string
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
 }
 
 // GetByID fetches a user.
@@ -106,18 +268,100 @@ func (s *UsersService) Get(ctx context.Context, user string) (*User, *Response,
 // Note: GetByID uses the undocumented GitHub API endpoint /user/:id.
 func (s *UsersService) GetByID(ctx context.Context, id int64) (*User, *Response, error) {
 	u := fmt.Sprintf("user/%d", id)
-	req, err := s.client.NewRequest("GET", u, nil)
+	return do[User](ctx, s.client, "GET", u, nil, nil)
+}
+
+// GetByNodeID fetches a user by its GraphQL node ID.
+//
+// There is no REST endpoint to look up a node ID, so the database ID of the
+// user is decoded from the node ID locally and the user is then fetched with
+// GetByID. Both the legacy format (e.g. "MDQ6VXNlcjE=") and the current format
+// (e.g. "U_kgDOAAjmPw") of user node IDs are supported.
+func (s *UsersService) GetByNodeID(ctx context.Context, nodeID string) (*User, *Response, error) {
+	id, err := parseUserNodeID(nodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.GetByID(ctx, id)
+}
+
+// parseUserNodeID extracts the database ID of a user from its GraphQL node ID.
+func parseUserNodeID(nodeID string) (int64, error) {
+	invalid := fmt.Errorf("invalid user node ID %q", nodeID)
+
+	// Current format: "U_" followed by the base64url encoding of the
+	// MessagePack array [0, id].
+	if rest, ok := strings.CutPrefix(nodeID, "U_"); ok {
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(rest, "="))
+		if err != nil || len(b) < 3 || b[0] != 0x92 || b[1] != 0x00 {
+			return 0, invalid
+		}
+		b = b[2:]
+		switch {
+		case b[0] < 0x80 && len(b) == 1: // positive fixint
+			return int64(b[0]), nil
+		case b[0] == 0xcc && len(b) == 2: // uint 8
+			return int64(b[1]), nil
+		case b[0] == 0xcd && len(b) == 3: // uint 16
+			return int64(binary.BigEndian.Uint16(b[1:])), nil
+		case b[0] == 0xce && len(b) == 5: // uint 32
+			return int64(binary.BigEndian.Uint32(b[1:])), nil
+		case b[0] == 0xcf && len(b) == 9: // uint 64
+			if id := binary.BigEndian.Uint64(b[1:]); id <= math.MaxInt64 {
+				return int64(id), nil
+			}
+		}
+		return 0, invalid
+	}
+
+	// Legacy format: the base64 encoding of "04:User<id>".
+	b, err := base64.StdEncoding.DecodeString(nodeID)
+	if err != nil {
+		return 0, invalid
+	}
+	rest, ok := strings.CutPrefix(string(b), "04:User")
+	if !ok {
+		return 0, invalid
+	}
+	id, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, invalid
+	}
+	return id, nil
+}
+
+// GetAvatar fetches the avatar image of a user. Passing the empty string will
+// fetch the avatar of the authenticated user.
+//
+// The user is fetched first to discover User.AvatarURL, and the image bytes
+// are then downloaded from that URL, following any redirect to the avatars CDN.
+// The client's credentials are not sent to the CDN. The returned Response is
+// the one for the image download.
+func (s *UsersService) GetAvatar(ctx context.Context, user string) ([]byte, *Response, error) {
+	usr, resp, err := s.Get(ctx, user)
+	if err != nil {
+		return nil, resp, err
+	}
+	if usr.GetAvatarURL() == "" {
+		return nil, resp, fmt.Errorf("user %q has no avatar URL", usr.GetLogin())
+	}
+
+	httpResp, err := s.client.getURL(ctx, usr.GetAvatarURL(), "image/*")
 	if err != nil {
 		return nil, nil, err
 	}
+	defer httpResp.Body.Close()
 
-	user := new(User)
-	resp, err := s.client.Do(ctx, req, user)
+	resp = newResponse(httpResp)
+	if err := CheckResponse(httpResp); err != nil {
+		return nil, resp, err
+	}
+	avatar, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return user, resp, nil
+	return avatar, resp, nil
 }
 
 // HovercardOptions specifies optional parameters to the UsersService.GetHovercard
@@ -131,6 +375,23 @@ type HovercardOptions struct {
 	SubjectID string `url:"subject_id"`
 }
 
+// validate reports an error if SubjectType and SubjectID are not set together,
+// or if SubjectType is not one of the values accepted by the API.
+func (o *HovercardOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+	if (o.SubjectType == "") != (o.SubjectID == "") {
+		return errors.New("HovercardOptions.SubjectType and HovercardOptions.SubjectID must be set together")
+	}
+	switch o.SubjectType {
+	case "", "organization", "repository", "issue", "pull_request":
+		return nil
+	default:
+		return fmt.Errorf("HovercardOptions.SubjectType %q is invalid; want one of organization, repository, issue, pull_request", o.SubjectType)
+	}
+}
+
 // Hovercard represents hovercard information about a user.
 type Hovercard struct {
 	Contexts []*UserContext `json:"contexts,omitempty"`
@@ -143,10 +404,23 @@ type UserContext struct {
 }
 
 // GetHovercard fetches contextual information about user. It requires authentication
-// via Basic Auth or via OAuth with the repo scope.
+// via Basic Auth or via OAuth with the repo scope. If the client was created
+// with WithHovercardCache, results may be served from its cache.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/users#get-contextual-information-for-a-user
 func (s *UsersService) GetHovercard(ctx context.Context, user string, opts *HovercardOptions) (*Hovercard, *Response, error) {
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	cache := s.client.hovercards
+	key := newHovercardKey(user, opts)
+	if cache != nil {
+		if hc, resp := cache.get(key); hc != nil {
+			return hc, resp, nil
+		}
+	}
+
 	u := fmt.Sprintf("users/%v/hovercard", user)
 	u, err := addOptions(u, opts)
 	if err != nil {
@@ -164,15 +438,57 @@ func (s *UsersService) GetHovercard(ctx context.Context, user string, opts *Hove
 		return nil, resp, err
 	}
 
+	if cache != nil {
+		cache.put(key, hc, resp)
+	}
 	return hc, resp, nil
 }
 
+// GetHovercardForOrg fetches contextual information about user in the context
+// of the organization with the given ID.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-contextual-information-for-a-user
+func (s *UsersService) GetHovercardForOrg(ctx context.Context, user, orgID string) (*Hovercard, *Response, error) {
+	return s.GetHovercard(ctx, user, &HovercardOptions{SubjectType: "organization", SubjectID: orgID})
+}
+
+// GetHovercardForRepo fetches contextual information about user in the context
+// of the repository with the given ID.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-contextual-information-for-a-user
+func (s *UsersService) GetHovercardForRepo(ctx context.Context, user, repoID string) (*Hovercard, *Response, error) {
+	return s.GetHovercard(ctx, user, &HovercardOptions{SubjectType: "repository", SubjectID: repoID})
+}
+
+// GetHovercardForIssue fetches contextual information about user in the context
+// of the issue with the given ID.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-contextual-information-for-a-user
+func (s *UsersService) GetHovercardForIssue(ctx context.Context, user, issueID string) (*Hovercard, *Response, error) {
+	return s.GetHovercard(ctx, user, &HovercardOptions{SubjectType: "issue", SubjectID: issueID})
+}
+
+// GetHovercardForPullRequest fetches contextual information about user in the
+// context of the pull request with the given ID.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-contextual-information-for-a-user
+func (s *UsersService) GetHovercardForPullRequest(ctx context.Context, user, pullRequestID string) (*Hovercard, *Response, error) {
+	return s.GetHovercard(ctx, user, &HovercardOptions{SubjectType: "pull_request", SubjectID: pullRequestID})
+}
+
 // UserListOptions specifies optional parameters to the UsersService.ListAll
 // method.
 type UserListOptions struct {
 	// ID of the last user seen
 	Since int64 `url:"since,omitempty"`
 
+	// Type, if set, only keeps accounts of the given type ("User" or
+	// "Organization"). The API has no server-side filter for this, so the
+	// filtering happens after each page is fetched, and a page may hold fewer
+	// users than requested or none at all. To paginate, advance Since with
+	// Response.NextPage rather than with the ID of the last user returned.
+	Type string `url:"-"`
+
 	// Note: Pagination is powered exclusively by the Since parameter,
 	// ListOptions.Page has no effect.
 	// ListOptions.PerPage controls an undocumented GitHub API parameter.
@@ -181,25 +497,153 @@ type UserListOptions struct {
 
 // ListAll lists all GitHub users.
 //
-// To paginate through all users, populate 'Since' with the ID of the last user.
+// To paginate through all users, populate 'Since' with Response.NextPage, the
+// ID of the last user of the page as given by the API.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/users#list-users
 func (s *UsersService) ListAll(ctx context.Context, opts *UserListOptions) ([]*User, *Response, error) {
+	users, _, resp, err := s.listAll(ctx, opts)
+	return users, resp, err
+}
+
+// NextSince returns the highest ID among users, which is the value of
+// UserListOptions.Since to use to request the page following users.
+// Users without an ID are ignored. It returns 0 if no user has an ID.
+//
+// NextSince is only right for pages listed without UserListOptions.Type. A
+// filtered page lacks the users that were dropped, possibly all of them, so
+// NextSince would fetch some users again or start over from the first one;
+// use Response.NextPage instead.
+func NextSince(users []*User) int64 {
+	var since int64
+	for _, user := range users {
+		if id := user.GetID(); id > since {
+			since = id
+		}
+	}
+	return since
+}
+
+// listAll implements ListAll. Besides the users kept after filtering by
+// opts.Type, it returns the ID of the last user of the unfiltered page, which
+// is the value to use as Since for the next page.
+func (s *UsersService) listAll(ctx context.Context, opts *UserListOptions) ([]*User, int64, *Response, error) {
 	u, err := addOptions("users", opts)
 	if err != nil {
-		return nil, nil, err
+		return nil, 0, nil, err
 	}
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, 0, nil, err
 	}
 
 	var users []*User
 	resp, err := s.client.Do(ctx, req, &users)
 	if err != nil {
-		return nil, resp, err
+		return nil, 0, resp, err
+	}
+
+	var lastID int64
+	if len(users) > 0 {
+		lastID = users[len(users)-1].GetID()
+	}
+
+	if opts != nil && opts.Type != "" {
+		filtered := make([]*User, 0, len(users))
+		for _, user := range users {
+			if user.GetType() == opts.Type {
+				filtered = append(filtered, user)
+			}
+		}
+		users = filtered
+	}
+
+	return users, lastID, resp, nil
+}
+
+// ListAllSince lists the GitHub users whose ID is greater than since and at
+// most until, paging through ListAll until a page goes past until. Users
+// beyond until on the last page are dropped. opts.Since is ignored; the other
+// fields of opts apply to every page.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#list-users
+func (s *UsersService) ListAllSince(ctx context.Context, since, until int64, opts *UserListOptions) ([]*User, error) {
+	var o UserListOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.Since = since
+
+	var users []*User
+	for o.Since < until {
+		page, lastID, _, err := s.listAll(ctx, &o)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range page {
+			if user.GetID() <= until {
+				users = append(users, user)
+			}
+		}
+
+		if lastID <= o.Since {
+			// The page was empty, or its last ID did not advance the cursor.
+			break
+		}
+		o.Since = lastID
+	}
+
+	return users, nil
+}
+
+// ListAllChan lists all GitHub users, streaming them on the returned channel
+// as each page arrives. Pagination is driven internally by setting
+// UserListOptions.Since to the ID of the last user of each page, starting
+// from opts.Since.
+//
+// Both channels are closed once all users have been sent, or when an error
+// occurs or ctx is canceled. At most one error is sent on the error channel.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#list-users
+func (s *UsersService) ListAllChan(ctx context.Context, opts *UserListOptions) (<-chan *User, <-chan error) {
+	users := make(chan *User)
+	errs := make(chan error, 1)
+
+	var o UserListOptions
+	if opts != nil {
+		o = *opts
 	}
 
-	return users, resp, nil
+	go func() {
+		defer close(errs)
+		defer close(users)
+
+		for {
+			page, since, _, err := s.listAll(ctx, &o)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, u := range page {
+				select {
+				case users <- u:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			if since <= o.Since {
+				// The page was empty, or its last ID did not advance the
+				// cursor; stop rather than requesting the same page forever.
+				return
+			}
+			o.Since = since
+		}
+	}()
+
+	return users, errs
 }
@@ -0,0 +1,37 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListAttestations returns a collection of artifact attestations
+// with a given subject digest that are associated with repositories
+// owned by a user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/attestations#list-attestations
+func (s *UsersService) ListAttestations(ctx context.Context, user, subjectDigest string, opts *ListOptions) (*AttestationsResponse, *Response, error) {
+	u := fmt.Sprintf("users/%v/attestations/%v", user, subjectDigest)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var attestations *AttestationsResponse
+	resp, err := s.client.Do(ctx, req, &attestations)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return attestations, resp, nil
+}
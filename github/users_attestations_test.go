@@ -0,0 +1,75 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsersService_ListAttestations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/attestations/digest", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"per_page": "2"})
+		fmt.Fprint(w, `{
+			"attestations": [
+				{
+					"repository_id": 1,
+					"bundle": {"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json"}
+				},
+				{
+					"repository_id": 2,
+					"bundle": {}
+				}
+			]
+		}`)
+	})
+
+	opts := &ListOptions{PerPage: 2}
+	ctx := context.Background()
+	attestations, _, err := client.Users.ListAttestations(ctx, "u", "digest", opts)
+	if err != nil {
+		t.Errorf("Users.ListAttestations returned error: %v", err)
+	}
+
+	want := &AttestationsResponse{
+		Attestations: []*Attestation{
+			{
+				RepositoryID: 1,
+				Bundle:       json.RawMessage(`{"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json"}`),
+			},
+			{
+				RepositoryID: 2,
+				Bundle:       json.RawMessage(`{}`),
+			},
+		},
+	}
+	if !cmp.Equal(attestations, want) {
+		t.Errorf("Users.ListAttestations returned %+v, want %+v", attestations, want)
+	}
+
+	const methodName = "ListAttestations"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.ListAttestations(ctx, "\n", "\n", opts)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.ListAttestations(ctx, "u", "digest", opts)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
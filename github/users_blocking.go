@@ -8,8 +8,13 @@ package github
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
+// bulkBlockConcurrency is the number of concurrent requests made by
+// UsersService.BlockUsers and UsersService.UnblockUsers.
+const bulkBlockConcurrency = 4
+
 // ListBlockedUsers lists all the blocked users by the authenticated user.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/blocking#list-users-blocked-by-the-authenticated-user
@@ -56,6 +61,27 @@ func (s *UsersService) IsBlocked(ctx context.Context, user string) (bool, *Respo
 	return isBlocked, resp, err
 }
 
+// IsBlockedByOrg reports whether specified user is blocked by an organization.
+// It is the counterpart of IsBlocked for blocks made by an organization rather
+// than by the authenticated user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/blocking#check-if-a-user-is-blocked-by-an-organization
+func (s *UsersService) IsBlockedByOrg(ctx context.Context, org, user string) (bool, *Response, error) {
+	u := fmt.Sprintf("orgs/%v/blocks/%v", org, user)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// TODO: remove custom Accept header when this API fully launches.
+	req.Header.Set("Accept", mediaTypeBlockUsersPreview)
+
+	resp, err := s.client.Do(ctx, req, nil)
+	isBlocked, err := parseBoolResponse(err)
+	return isBlocked, resp, err
+}
+
 // BlockUser blocks specified user for the authenticated user.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/blocking#block-a-user
@@ -89,3 +115,55 @@ func (s *UsersService) UnblockUser(ctx context.Context, user string) (*Response,
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// BlockUsers blocks each of logins for the authenticated user, making a few
+// requests concurrently. It returns the logins that were blocked, in the order
+// given, and the error for each login that was not. If ctx is canceled, no
+// further requests are made, and the logins that were not attempted map to
+// ctx.Err(). Duplicate logins are blocked once.
+func (s *UsersService) BlockUsers(ctx context.Context, logins []string) (blocked []string, errs map[string]error) {
+	return s.bulkBlock(ctx, logins, s.BlockUser)
+}
+
+// UnblockUsers unblocks each of logins for the authenticated user, making a
+// few requests concurrently. It reports its results like BlockUsers.
+func (s *UsersService) UnblockUsers(ctx context.Context, logins []string) (unblocked []string, errs map[string]error) {
+	return s.bulkBlock(ctx, logins, s.UnblockUser)
+}
+
+// bulkBlock calls fn for each distinct login in logins, with at most
+// bulkBlockConcurrency calls in flight, and returns the logins for which fn
+// succeeded, in order, along with the errors of the others.
+func (s *UsersService) bulkBlock(ctx context.Context, logins []string, fn func(context.Context, string) (*Response, error)) ([]string, map[string]error) {
+	var (
+		mu   sync.Mutex
+		done = make(map[string]bool, len(logins))
+		errs = make(map[string]error)
+	)
+
+	logins = distinct(logins)
+	unsent := forEachConcurrently(ctx, logins, bulkBlockConcurrency, func(login string) {
+		_, err := fn(ctx, login)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[login] = err
+		} else {
+			done[login] = true
+		}
+	})
+
+	for _, login := range unsent {
+		errs[login] = ctx.Err()
+	}
+	var succeeded []string
+	for _, login := range logins {
+		if done[login] {
+			succeeded = append(succeeded, login)
+		}
+	}
+	if len(errs) == 0 {
+		errs = nil
+	}
+	return succeeded, errs
+}
@@ -7,8 +7,11 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -35,7 +38,7 @@ func TestUsersService_ListBlockedUsers(t *testing.T) {
 	}
 
 	want := []*User{{Login: String("octocat")}}
-	if !cmp.Equal(blockedUsers, want) {
+	if !cmp.Equal(blockedUsers, want, cmpUsers) {
 		t.Errorf("Users.ListBlockedUsers returned %+v, want %+v", blockedUsers, want)
 	}
 
@@ -83,6 +86,59 @@ func TestUsersService_IsBlocked(t *testing.T) {
 	})
 }
 
+func TestUsersService_IsBlockedByOrg(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/blocks/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeBlockUsersPreview)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	isBlocked, _, err := client.Users.IsBlockedByOrg(ctx, "o", "u")
+	if err != nil {
+		t.Errorf("Users.IsBlockedByOrg returned error: %v", err)
+	}
+	if want := true; isBlocked != want {
+		t.Errorf("Users.IsBlockedByOrg returned %+v, want %+v", isBlocked, want)
+	}
+
+	const methodName = "IsBlockedByOrg"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.IsBlockedByOrg(ctx, "\n", "\n")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.IsBlockedByOrg(ctx, "o", "u")
+		if got {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want false", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestUsersService_IsBlockedByOrg_notBlocked(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/blocks/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	isBlocked, _, err := client.Users.IsBlockedByOrg(ctx, "o", "u")
+	if err != nil {
+		t.Errorf("Users.IsBlockedByOrg returned error: %v", err)
+	}
+	if want := false; isBlocked != want {
+		t.Errorf("Users.IsBlockedByOrg returned %+v, want %+v", isBlocked, want)
+	}
+}
+
 func TestUsersService_BlockUser(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -136,3 +192,107 @@ func TestUsersService_UnblockUser(t *testing.T) {
 		return client.Users.UnblockUser(ctx, "u")
 	})
 }
+
+func TestUsersService_BlockUsers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	mux.HandleFunc("/user/blocks/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		login := strings.TrimPrefix(r.URL.Path, "/user/blocks/")
+		mu.Lock()
+		calls[login]++
+		mu.Unlock()
+		switch login {
+		case "missing":
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+		case "self":
+			http.Error(w, `{"message":"Validation Failed"}`, http.StatusUnprocessableEntity)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	blocked, errs := client.Users.BlockUsers(ctx, []string{"a", "missing", "b", "self", "a", "c"})
+
+	if want := []string{"a", "b", "c"}; !cmp.Equal(blocked, want) {
+		t.Errorf("Users.BlockUsers blocked %v, want %v", blocked, want)
+	}
+	if len(errs) != 2 {
+		t.Errorf("Users.BlockUsers returned errors %v, want errors for missing and self", errs)
+	}
+	for login, status := range map[string]int{"missing": http.StatusNotFound, "self": http.StatusUnprocessableEntity} {
+		var errResp *ErrorResponse
+		if !errors.As(errs[login], &errResp) || errResp.Response.StatusCode != status {
+			t.Errorf("Users.BlockUsers error for %v = %v, want a %v *ErrorResponse", login, errs[login], status)
+		}
+	}
+	if calls["a"] != 1 {
+		t.Errorf("Users.BlockUsers blocked a %v times, want 1", calls["a"])
+	}
+}
+
+func TestUsersService_BlockUsers_canceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var calls int
+	mux.HandleFunc("/user/blocks/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		cancel()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	logins := make([]string, 20)
+	for i := range logins {
+		logins[i] = fmt.Sprintf("u%v", i)
+	}
+	blocked, errs := client.Users.BlockUsers(ctx, logins)
+
+	// Wait for the handlers of the canceled requests to finish, so that
+	// calls is final.
+	teardown()
+	mu.Lock()
+	defer mu.Unlock()
+	if calls > bulkBlockConcurrency {
+		t.Errorf("Users.BlockUsers made %v requests, want at most %v", calls, bulkBlockConcurrency)
+	}
+	if got := len(blocked) + len(errs); got != len(logins) {
+		t.Errorf("Users.BlockUsers reported %v logins, want all %v", got, len(logins))
+	}
+	if !errors.Is(errs["u19"], context.Canceled) {
+		t.Errorf("Users.BlockUsers error for u19 = %v, want %v", errs["u19"], context.Canceled)
+	}
+}
+
+func TestUsersService_UnblockUsers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/blocks/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		if r.URL.Path == "/user/blocks/missing" {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	unblocked, errs := client.Users.UnblockUsers(ctx, []string{"a", "missing"})
+	if want := []string{"a"}; !cmp.Equal(unblocked, want) {
+		t.Errorf("Users.UnblockUsers unblocked %v, want %v", unblocked, want)
+	}
+	if _, ok := errs["missing"]; !ok || len(errs) != 1 {
+		t.Errorf("Users.UnblockUsers returned errors %v, want only an error for missing", errs)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// contributionsQuery is the GraphQL query used by UsersService.GetContributions.
+const contributionsQuery = `query($login: String!) {
+  user(login: $login) {
+    contributionsCollection {
+      contributionCalendar {
+        totalContributions
+        weeks {
+          firstDay
+          contributionDays {
+            date
+            weekday
+            contributionCount
+            color
+          }
+        }
+      }
+    }
+  }
+}`
+
+// ContributionCalendar represents the contributions graph shown on a user's
+// profile, covering the past year.
+type ContributionCalendar struct {
+	TotalContributions *int                `json:"totalContributions,omitempty"`
+	Weeks              []*ContributionWeek `json:"weeks,omitempty"`
+}
+
+// ContributionWeek represents a week of a ContributionCalendar.
+type ContributionWeek struct {
+	// FirstDay is the date of the first day of the week, as YYYY-MM-DD.
+	FirstDay         *string            `json:"firstDay,omitempty"`
+	ContributionDays []*ContributionDay `json:"contributionDays,omitempty"`
+}
+
+// ContributionDay represents a day of a ContributionCalendar.
+type ContributionDay struct {
+	// Date is the date of the day, as YYYY-MM-DD.
+	Date *string `json:"date,omitempty"`
+	// Weekday is the day of the week, from 0 for Sunday to 6 for Saturday.
+	Weekday           *int `json:"weekday,omitempty"`
+	ContributionCount *int `json:"contributionCount,omitempty"`
+	// Color is the hex code of the color the day is shown in on the graph.
+	Color *string `json:"color,omitempty"`
+}
+
+// GetContributions fetches the contributions graph of user for the past
+// year. The REST API does not expose it, so it is read from the GraphQL API,
+// which requires the client to be authenticated with a token, such as one set
+// with WithAuthToken.
+//
+// GitHub API docs: https://docs.github.com/en/graphql/reference/objects#contributioncalendar
+func (s *UsersService) GetContributions(ctx context.Context, user string) (*ContributionCalendar, *Response, error) {
+	var data struct {
+		User *struct {
+			ContributionsCollection struct {
+				ContributionCalendar *ContributionCalendar `json:"contributionCalendar"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	}
+	resp, err := s.client.GraphQL(ctx, contributionsQuery, map[string]interface{}{"login": user}, &data)
+	if err != nil {
+		return nil, resp, err
+	}
+	if data.User == nil || data.User.ContributionsCollection.ContributionCalendar == nil {
+		return nil, resp, fmt.Errorf("no contributions found for user %q", user)
+	}
+
+	return data.User.ContributionsCollection.ContributionCalendar, resp, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// contributionsResponse is a GraphQL response recorded for the query sent by
+// GetContributions, trimmed to its first two weeks.
+const contributionsResponse = `{
+  "data": {
+    "user": {
+      "contributionsCollection": {
+        "contributionCalendar": {
+          "totalContributions": 5,
+          "weeks": [
+            {
+              "firstDay": "2022-10-16",
+              "contributionDays": [
+                {"date": "2022-10-16", "weekday": 0, "contributionCount": 0, "color": "#ebedf0"},
+                {"date": "2022-10-17", "weekday": 1, "contributionCount": 3, "color": "#40c463"}
+              ]
+            },
+            {
+              "firstDay": "2022-10-23",
+              "contributionDays": [
+                {"date": "2022-10-23", "weekday": 0, "contributionCount": 2, "color": "#9be9a8"}
+              ]
+            }
+          ]
+        }
+      }
+    }
+  }
+}`
+
+func TestUsersService_GetContributions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if body.Query != contributionsQuery {
+			t.Errorf("Request query = %q, want %q", body.Query, contributionsQuery)
+		}
+		if want := map[string]interface{}{"login": "u"}; !cmp.Equal(body.Variables, want) {
+			t.Errorf("Request variables = %v, want %v", body.Variables, want)
+		}
+		fmt.Fprint(w, contributionsResponse)
+	})
+
+	ctx := context.Background()
+	calendar, _, err := client.Users.GetContributions(ctx, "u")
+	if err != nil {
+		t.Fatalf("Users.GetContributions returned error: %v", err)
+	}
+
+	want := &ContributionCalendar{
+		TotalContributions: Int(5),
+		Weeks: []*ContributionWeek{
+			{
+				FirstDay: String("2022-10-16"),
+				ContributionDays: []*ContributionDay{
+					{Date: String("2022-10-16"), Weekday: Int(0), ContributionCount: Int(0), Color: String("#ebedf0")},
+					{Date: String("2022-10-17"), Weekday: Int(1), ContributionCount: Int(3), Color: String("#40c463")},
+				},
+			},
+			{
+				FirstDay: String("2022-10-23"),
+				ContributionDays: []*ContributionDay{
+					{Date: String("2022-10-23"), Weekday: Int(0), ContributionCount: Int(2), Color: String("#9be9a8")},
+				},
+			},
+		},
+	}
+	if !cmp.Equal(calendar, want) {
+		t.Errorf("Users.GetContributions returned %+v, want %+v", calendar, want)
+	}
+
+	const methodName = "GetContributions"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.GetContributions(ctx, "u")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestUsersService_GetContributions_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"data": {"user": null},
+			"errors": [{"type": "NOT_FOUND", "path": ["user"], "message": "Could not resolve to a User with the login of 'u'."}]
+		}`)
+	})
+
+	calendar, resp, err := client.Users.GetContributions(context.Background(), "u")
+	var gqlErr *GraphQLError
+	if !errors.As(err, &gqlErr) || gqlErr.Errors[0].Type != "NOT_FOUND" {
+		t.Errorf("Users.GetContributions returned error %v, want a NOT_FOUND *GraphQLError", err)
+	}
+	if calendar != nil {
+		t.Errorf("Users.GetContributions returned %+v, want nil", calendar)
+	}
+	if resp == nil {
+		t.Error("Users.GetContributions returned a nil response, want the GraphQL response")
+	}
+}
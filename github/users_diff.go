@@ -0,0 +1,60 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DiffUsers compares two versions of a user, such as the results of two
+// calls to Users.Get, and returns the fields that differ, keyed by field
+// name, as pairs of the old and the new value. Pointer fields are
+// dereferenced, and an unset field is reported as nil, so a field that was
+// added or removed maps to a pair holding one nil. The fields returned by
+// User.Raw, fields not encoded to JSON, and URL fields, which change along
+// with the login, are skipped. A nil user is treated as one with no fields
+// set. If nothing changed, the returned map is empty.
+func DiffUsers(oldUser, newUser *User) map[string][2]interface{} {
+	if oldUser == nil {
+		oldUser = &User{}
+	}
+	if newUser == nil {
+		newUser = &User{}
+	}
+
+	diff := make(map[string][2]interface{})
+	oldV, newV := reflect.ValueOf(oldUser).Elem(), reflect.ValueOf(newUser).Elem()
+	t := oldV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" || strings.HasSuffix(field.Name, "URL") {
+			continue
+		}
+		o, n := diffValue(oldV.Field(i)), diffValue(newV.Field(i))
+		if !reflect.DeepEqual(o, n) {
+			diff[field.Name] = [2]interface{}{o, n}
+		}
+	}
+	return diff
+}
+
+// diffValue returns the value DiffUsers reports for v: the value v points
+// to if v is a pointer, or nil if v is unset.
+func diffValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	return v.Interface()
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffUsers(t *testing.T) {
+	oldUser := &User{
+		Login:     String("old"),
+		ID:        Int64(1),
+		Name:      String("N"),
+		Bio:       String("bio"),
+		Followers: Int(1),
+		HTMLURL:   String("https://github.com/old"),
+		URL:       String("https://api.github.com/users/old"),
+		Plan:      &Plan{Name: String("free")},
+		rawJSON:   json.RawMessage(`{"a":1}`),
+	}
+	newUser := &User{
+		Login:     String("new"),
+		ID:        Int64(1),
+		Name:      String("N"),
+		Company:   String("C"),
+		Followers: Int(2),
+		HTMLURL:   String("https://github.com/new"),
+		URL:       String("https://api.github.com/users/new"),
+		Plan:      &Plan{Name: String("pro")},
+		rawJSON:   json.RawMessage(`{"a":2}`),
+	}
+
+	want := map[string][2]interface{}{
+		"Login":     {"old", "new"},
+		"Company":   {nil, "C"},   // added
+		"Bio":       {"bio", nil}, // removed
+		"Followers": {1, 2},
+		"Plan":      {Plan{Name: String("free")}, Plan{Name: String("pro")}},
+	}
+	if got := DiffUsers(oldUser, newUser); !cmp.Equal(got, want) {
+		t.Errorf("DiffUsers returned %v, want %v", got, want)
+	}
+
+	if got := DiffUsers(oldUser, oldUser); len(got) != 0 {
+		t.Errorf("DiffUsers of a user with itself returned %v, want no changes", got)
+	}
+}
+
+func TestDiffUsers_nil(t *testing.T) {
+	user := &User{Login: String("u"), Permissions: map[string]bool{"admin": true}}
+
+	want := map[string][2]interface{}{
+		"Login":       {nil, "u"},
+		"Permissions": {nil, map[string]bool{"admin": true}},
+	}
+	if got := DiffUsers(nil, user); !cmp.Equal(got, want) {
+		t.Errorf("DiffUsers(nil, user) returned %v, want %v", got, want)
+	}
+
+	want = map[string][2]interface{}{
+		"Login":       {"u", nil},
+		"Permissions": {map[string]bool{"admin": true}, nil},
+	}
+	if got := DiffUsers(user, nil); !cmp.Equal(got, want) {
+		t.Errorf("DiffUsers(user, nil) returned %v, want %v", got, want)
+	}
+}
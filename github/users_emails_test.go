@@ -104,6 +104,7 @@ func TestUsersService_DeleteEmails(t *testing.T) {
 		assertNilError(t, json.NewDecoder(r.Body).Decode(&v))
 
 		testMethod(t, r, "DELETE")
+		testHeader(t, r, "Content-Type", "application/json")
 		if !cmp.Equal(v, input) {
 			t.Errorf("Request body = %+v, want %+v", v, input)
 		}
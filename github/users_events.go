@@ -0,0 +1,56 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+)
+
+// UserEventListOptions specifies the optional parameters to the
+// UsersService.ListEventsByDate method.
+type UserEventListOptions struct {
+	DateRangeOptions
+
+	// ListOptions sets the page to start from and the size of the pages
+	// fetched.
+	ListOptions
+}
+
+// ListEventsByDate lists the events performed by a user, like
+// ActivityService.ListEventsPerformedByUser, that were created in the date
+// range given in opts. The API cannot filter events by date, so it fetches
+// pages of events, which come newest first, until it reaches one created
+// before opts.Since, or the last page, and filters them itself. It returns the response of the last page fetched.
+//
+// GitHub API docs: https://docs.github.com/en/rest/activity/events#list-events-for-the-authenticated-user
+func (s *UsersService) ListEventsByDate(ctx context.Context, user string, opts *UserEventListOptions) ([]*Event, *Response, error) {
+	if opts == nil {
+		opts = new(UserEventListOptions)
+	}
+	listOpts := opts.ListOptions
+
+	var events []*Event
+	for {
+		page, resp, err := (*ActivityService)(s).ListEventsPerformedByUser(ctx, user, false, &listOpts)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, event := range page {
+			created := event.GetCreatedAt().Time
+			if opts.Since != nil && created.Before(opts.Since.Time) {
+				return events, resp, nil
+			}
+			if opts.Until != nil && !created.Before(opts.Until.Time) {
+				continue
+			}
+			events = append(events, event)
+		}
+		if resp.NextPage == 0 {
+			return events, resp, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsersService_ListEventsByDate(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	var pages []string
+	mux.HandleFunc("/users/u/events", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		page := r.FormValue("page")
+		pages = append(pages, page)
+		switch page {
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s/users/u/events?page=3>; rel="next"`, serverURL, baseURLPath))
+			fmt.Fprint(w, `[{"id":"4","created_at":"2023-03-01T00:00:00Z"},{"id":"3","created_at":"2023-02-01T00:00:00Z"}]`)
+		case "3":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s/users/u/events?page=4>; rel="next"`, serverURL, baseURLPath))
+			fmt.Fprint(w, `[{"id":"2","created_at":"2023-01-15T00:00:00Z"},{"id":"1","created_at":"2022-12-01T00:00:00Z"}]`)
+		default:
+			t.Errorf("fetched page %q, want pages 2 and 3 only", page)
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	opt := &UserEventListOptions{
+		DateRangeOptions: DateRangeOptions{
+			Since: &Timestamp{time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			Until: &Timestamp{time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		ListOptions: ListOptions{Page: 2},
+	}
+	ctx := context.Background()
+	events, _, err := client.Users.ListEventsByDate(ctx, "u", opt)
+	if err != nil {
+		t.Errorf("Users.ListEventsByDate returned error: %v", err)
+	}
+
+	want := []*Event{
+		{ID: String("3"), CreatedAt: &Timestamp{time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)}},
+		{ID: String("2"), CreatedAt: &Timestamp{time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC)}},
+	}
+	if !cmp.Equal(events, want) {
+		t.Errorf("Users.ListEventsByDate returned %+v, want %+v", events, want)
+	}
+	if want := []string{"2", "3"}; !cmp.Equal(pages, want) {
+		t.Errorf("Users.ListEventsByDate fetched pages %q, want %q", pages, want)
+	}
+
+	const methodName = "ListEventsByDate"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.ListEventsByDate(ctx, "\n", opt)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.ListEventsByDate(ctx, "u", opt)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
@@ -8,10 +8,16 @@ package github
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
+// hydrateConcurrency is the number of concurrent requests made by
+// UsersService.HydrateUsers.
+const hydrateConcurrency = 4
+
 // ListFollowers lists the followers for a user. Passing the empty string will
-// fetch followers for the authenticated user.
+// fetch followers for the authenticated user. See HydrateUsers to fetch their
+// full profiles.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/followers#list-followers-of-the-authenticated-user
 // GitHub API docs: https://docs.github.com/en/rest/users/followers#list-followers-of-a-user
@@ -41,8 +47,22 @@ func (s *UsersService) ListFollowers(ctx context.Context, user string, opts *Lis
 	return users, resp, nil
 }
 
+// AllFollowers lists all the followers for a user, fetching every page of
+// ListFollowers with CollectPages. Passing the empty string will fetch
+// followers for the authenticated user. The first error encountered is
+// returned.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/followers#list-followers-of-the-authenticated-user
+// GitHub API docs: https://docs.github.com/en/rest/users/followers#list-followers-of-a-user
+func (s *UsersService) AllFollowers(ctx context.Context, user string) ([]*User, error) {
+	return CollectPages(ctx, func(ctx context.Context, opts *ListOptions) ([]*User, *Response, error) {
+		return s.ListFollowers(ctx, user, opts)
+	}, 0)
+}
+
 // ListFollowing lists the people that a user is following. Passing the empty
-// string will list people the authenticated user is following.
+// string will list people the authenticated user is following. See
+// HydrateUsers to fetch their full profiles.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/followers#list-the-people-the-authenticated-user-follows
 // GitHub API docs: https://docs.github.com/en/rest/users/followers#list-the-people-a-user-follows
@@ -72,6 +92,44 @@ func (s *UsersService) ListFollowing(ctx context.Context, user string, opts *Lis
 	return users, resp, nil
 }
 
+// HydrateUsers replaces each of users that has a login with the full profile
+// returned by Get, including fields such as Name and Bio that lists such as
+// ListFollowers leave out. This costs one request per user, made a few at a
+// time. It returns the first error encountered, after which no further
+// requests are made.
+func (s *UsersService) HydrateUsers(ctx context.Context, users []*User) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var indexes []int
+	for i, user := range users {
+		if user.GetLogin() != "" {
+			indexes = append(indexes, i)
+		}
+	}
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	forEachConcurrently(ctx, indexes, hydrateConcurrency, func(i int) {
+		full, _, err := s.Get(ctx, users[i].GetLogin())
+		if err != nil {
+			once.Do(func() {
+				firstErr = err
+				cancel()
+			})
+			return
+		}
+		users[i] = full
+	})
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
 // IsFollowing checks if "user" is following "target". Passing the empty
 // string for "user" will check if the authenticated user is following "target".
 //
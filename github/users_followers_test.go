@@ -7,6 +7,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -32,7 +33,7 @@ func TestUsersService_ListFollowers_authenticatedUser(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Users.ListFollowers returned %+v, want %+v", users, want)
 	}
 
@@ -67,7 +68,7 @@ func TestUsersService_ListFollowers_specifiedUser(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Users.ListFollowers returned %+v, want %+v", users, want)
 	}
 
@@ -95,6 +96,120 @@ func TestUsersService_ListFollowers_invalidUser(t *testing.T) {
 	testURLParseError(t, err)
 }
 
+func TestUsersService_AllFollowers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/followers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch page := r.FormValue("page"); page {
+		case "":
+			w.Header().Set("Link", `<https://api.github.com/users/u/followers?page=2>; rel="next"`)
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+		case "2":
+			w.Header().Set("Link", `<https://api.github.com/users/u/followers?page=3>; rel="next"`)
+			fmt.Fprint(w, `[{"id":3}]`)
+		case "3":
+			fmt.Fprint(w, `[{"id":4}]`)
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	})
+
+	ctx := context.Background()
+	users, err := client.Users.AllFollowers(ctx, "u")
+	if err != nil {
+		t.Errorf("Users.AllFollowers returned error: %v", err)
+	}
+
+	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}, {ID: Int64(3)}, {ID: Int64(4)}}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.AllFollowers returned %+v, want %+v", users, want)
+	}
+}
+
+func TestUsersService_AllFollowers_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/followers", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("page") == "" {
+			w.Header().Set("Link", `<https://api.github.com/users/u/followers?page=2>; rel="next"`)
+			fmt.Fprint(w, `[{"id":1}]`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ctx := context.Background()
+	users, err := client.Users.AllFollowers(ctx, "u")
+	if err == nil {
+		t.Error("Users.AllFollowers returned nil error, want error")
+	}
+	if users != nil {
+		t.Errorf("Users.AllFollowers returned %+v, want nil", users)
+	}
+}
+
+func TestUsersService_HydrateUsers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/followers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":1,"login":"a"},{"id":2,"login":"b"}]`)
+	})
+	mux.HandleFunc("/users/a", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":1,"login":"a","name":"A","bio":"bio a"}`)
+	})
+	mux.HandleFunc("/users/b", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":2,"login":"b","name":"B"}`)
+	})
+
+	ctx := context.Background()
+	users, _, err := client.Users.ListFollowers(ctx, "u", nil)
+	if err != nil {
+		t.Errorf("Users.ListFollowers returned error: %v", err)
+	}
+	want := []*User{{ID: Int64(1), Login: String("a")}, {ID: Int64(2), Login: String("b")}}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.ListFollowers returned %+v, want %+v", users, want)
+	}
+
+	if err := client.Users.HydrateUsers(ctx, users); err != nil {
+		t.Errorf("Users.HydrateUsers returned error: %v", err)
+	}
+	want = []*User{
+		{ID: Int64(1), Login: String("a"), Name: String("A"), Bio: String("bio a")},
+		{ID: Int64(2), Login: String("b"), Name: String("B")},
+	}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.HydrateUsers left %+v, want %+v", users, want)
+	}
+}
+
+func TestUsersService_HydrateUsers_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/a", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"login":"a","name":"A"}`)
+	})
+	mux.HandleFunc("/users/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	users := []*User{{ID: Int64(1), Login: String("a")}, {ID: Int64(2), Login: String("gone")}}
+	err := client.Users.HydrateUsers(ctx, users)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("Users.HydrateUsers returned error %v, want the 404 of hydrating gone", err)
+	}
+}
+
 func TestUsersService_ListFollowing_authenticatedUser(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -113,7 +228,7 @@ func TestUsersService_ListFollowing_authenticatedUser(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Users.ListFollowing returned %+v, want %+v", users, want)
 	}
 
@@ -148,7 +263,7 @@ func TestUsersService_ListFollowing_specifiedUser(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(1)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Users.ListFollowing returned %+v, want %+v", users, want)
 	}
 
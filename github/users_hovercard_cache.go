@@ -0,0 +1,121 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// hovercardCacheTTL is how long a cached hovercard is returned before it is
+// fetched again.
+const hovercardCacheTTL = 5 * time.Minute
+
+// WithHovercardCache returns a copy of the client whose Users.GetHovercard
+// keeps up to size results, keyed by user, subject type and subject ID, and
+// returns them for hovercardCacheTTL (five minutes) without contacting the
+// API. The Response returned alongside a cached hovercard is the one it was
+// read from, with FromCache set. Failed lookups are not cached.
+//
+// When the cache is full, the least recently used hovercard is evicted. The
+// cache belongs to the returned client only; the returned Hovercard values
+// are shared between callers and must not be modified.
+func (c *Client) WithHovercardCache(size int) *Client {
+	c2 := c.copy()
+	defer c2.initialize()
+	if size > 0 {
+		c2.hovercards = newHovercardCache(size)
+	}
+	return c2
+}
+
+// hovercardKey identifies a cached hovercard.
+type hovercardKey struct {
+	user, subjectType, subjectID string
+}
+
+// newHovercardKey returns the key of the hovercard of user in the context
+// given by opts.
+func newHovercardKey(user string, opts *HovercardOptions) hovercardKey {
+	key := hovercardKey{user: user}
+	if opts != nil {
+		key.subjectType, key.subjectID = opts.SubjectType, opts.SubjectID
+	}
+	return key
+}
+
+// hovercardEntry is a cached hovercard along with the response it was read
+// from.
+type hovercardEntry struct {
+	key     hovercardKey
+	card    *Hovercard
+	resp    *Response
+	expires time.Time
+}
+
+// hovercardCache is a least recently used cache of hovercards. It is safe for
+// concurrent use.
+type hovercardCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[hovercardKey]*list.Element // Values are *hovercardEntry.
+	order   *list.List                     // Most recently used first.
+
+	now func() time.Time // Replaced in tests.
+}
+
+func newHovercardCache(size int) *hovercardCache {
+	return &hovercardCache{
+		size:    size,
+		entries: make(map[hovercardKey]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// get returns the hovercard cached for key and a copy of its response with
+// FromCache set, or nils if there is no unexpired entry for key.
+func (c *hovercardCache) get(key hovercardKey) (*Hovercard, *Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	entry := elem.Value.(*hovercardEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, nil
+	}
+	c.order.MoveToFront(elem)
+
+	resp := *entry.resp
+	resp.FromCache = true
+	return entry.card, &resp
+}
+
+// put stores card and resp under key, evicting the least recently used entry
+// if the cache is full.
+func (c *hovercardCache) put(key hovercardKey, card *Hovercard, resp *Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &hovercardEntry{key: key, card: card, resp: resp, expires: c.now().Add(hovercardCacheTTL)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*hovercardEntry).key)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_WithHovercardCache(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := make(map[string]int)
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		key := r.URL.Path + "?" + r.URL.RawQuery
+		calls[key]++
+		fmt.Fprintf(w, `{"contexts":[{"message":%q}]}`, key)
+	})
+
+	client := base.WithHovercardCache(2)
+	ctx := context.Background()
+	get := func(user, orgID string, wantFromCache bool) {
+		t.Helper()
+		var opts *HovercardOptions
+		if orgID != "" {
+			opts = &HovercardOptions{SubjectType: "organization", SubjectID: orgID}
+		}
+		hc, resp, err := client.Users.GetHovercard(ctx, user, opts)
+		if err != nil {
+			t.Fatalf("Users.GetHovercard returned error: %v", err)
+		}
+		if resp.FromCache != wantFromCache {
+			t.Errorf("Users.GetHovercard(%q, %q) FromCache = %v, want %v", user, orgID, resp.FromCache, wantFromCache)
+		}
+		if resp.Response == nil || resp.StatusCode != http.StatusOK {
+			t.Errorf("Users.GetHovercard(%q, %q) returned response %+v, want the original 200 response", user, orgID, resp)
+		}
+		want := fmt.Sprintf("/users/%v/hovercard?", user)
+		if orgID != "" {
+			want += "subject_id=" + orgID + "&subject_type=organization"
+		}
+		if got := hc.Contexts[0].GetMessage(); got != want {
+			t.Errorf("Users.GetHovercard(%q, %q) returned the hovercard for %q, want %q", user, orgID, got, want)
+		}
+	}
+
+	get("u", "", false)
+	get("u", "", true)
+	get("u", "1", false) // A different subject is a miss.
+	get("u", "1", true)
+	get("v", "", false) // Evicts u without a subject, the least recently used.
+	get("u", "1", true)
+	get("u", "", false)
+
+	want := map[string]int{
+		"/users/u/hovercard?": 2,
+		"/users/u/hovercard?subject_id=1&subject_type=organization": 1,
+		"/users/v/hovercard?": 1,
+	}
+	if !cmp.Equal(calls, want) {
+		t.Errorf("server got requests %v, want %v", calls, want)
+	}
+
+	// The base client does not use the cache.
+	if _, resp, err := base.Users.GetHovercard(ctx, "v", nil); err != nil || resp.FromCache {
+		t.Errorf("base Users.GetHovercard returned resp %+v, err %v; want an uncached response", resp, err)
+	}
+}
+
+func TestClient_WithHovercardCache_expiry(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/users/u/hovercard", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"contexts":[]}`)
+	})
+
+	client := base.WithHovercardCache(1)
+	now := time.Now()
+	client.hovercards.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for _, step := range []struct {
+		advance       time.Duration
+		wantFromCache bool
+	}{
+		{0, false},
+		{hovercardCacheTTL - time.Second, true},
+		{time.Second, false},
+		{0, true},
+	} {
+		now = now.Add(step.advance)
+		_, resp, err := client.Users.GetHovercard(ctx, "u", nil)
+		if err != nil {
+			t.Fatalf("Users.GetHovercard returned error: %v", err)
+		}
+		if resp.FromCache != step.wantFromCache {
+			t.Errorf("after %v: FromCache = %v, want %v", step.advance, resp.FromCache, step.wantFromCache)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("server got %v requests, want 2", calls)
+	}
+}
+
+func TestClient_WithHovercardCache_errorNotCached(t *testing.T) {
+	base, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/users/u/hovercard", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"contexts":[]}`)
+	})
+
+	client := base.WithHovercardCache(1)
+	ctx := context.Background()
+	if _, _, err := client.Users.GetHovercard(ctx, "u", nil); err == nil {
+		t.Fatal("Users.GetHovercard returned no error, want one")
+	}
+	if _, resp, err := client.Users.GetHovercard(ctx, "u", nil); err != nil || resp.FromCache {
+		t.Errorf("Users.GetHovercard returned resp %+v, err %v; want an uncached response", resp, err)
+	}
+}
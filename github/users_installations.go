@@ -0,0 +1,20 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+)
+
+// GetInstallation gets the installation of the authenticated GitHub App on
+// the account of the specified user. It is equivalent to
+// AppsService.FindUserInstallation, and likewise requires the client to be
+// authenticated as the app, using a JWT.
+//
+// GitHub API docs: https://docs.github.com/en/rest/apps/apps#get-a-user-installation-for-the-authenticated-app
+func (s *UsersService) GetInstallation(ctx context.Context, user string) (*Installation, *Response, error) {
+	return (*AppsService)(s).FindUserInstallation(ctx, user)
+}
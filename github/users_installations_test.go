@@ -0,0 +1,62 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsersService_GetInstallation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/installation", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"id": 1,
+			"app_id": 2,
+			"target_id": 3,
+			"target_type": "User",
+			"account": {"login": "u", "id": 3, "type": "User"}
+		}`)
+	})
+
+	ctx := context.Background()
+	installation, _, err := client.Users.GetInstallation(ctx, "u")
+	if err != nil {
+		t.Errorf("Users.GetInstallation returned error: %v", err)
+	}
+
+	want := &Installation{
+		ID:         Int64(1),
+		AppID:      Int64(2),
+		TargetID:   Int64(3),
+		TargetType: String("User"),
+		Account:    &User{Login: String("u"), ID: Int64(3), Type: String("User")},
+	}
+	if !cmp.Equal(installation, want, cmpUsers) {
+		t.Errorf("Users.GetInstallation returned %+v, want %+v", installation, want)
+	}
+
+	const methodName = "GetInstallation"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.GetInstallation(ctx, "\n")
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.GetInstallation(ctx, "u")
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
@@ -7,7 +7,9 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // Key represents a public SSH key used to authenticate a user or deploy script.
@@ -78,10 +80,52 @@ func (s *UsersService) GetKey(ctx context.Context, id int64) (*Key, *Response, e
 	return key, resp, nil
 }
 
-// CreateKey adds a public key for the authenticated user.
+// keyAlgorithmPrefixes lists the prefixes of the SSH key algorithms
+// accepted by GitHub.
+var keyAlgorithmPrefixes = []string{
+	"ssh-rsa",
+	"ssh-ed25519",
+	"ecdsa-sha2-",
+	"sk-ecdsa-sha2-",
+	"sk-ssh-ed25519",
+}
+
+// validateKey reports whether key looks like a public SSH key in the
+// OpenSSH format, that is, a known algorithm followed by the encoded key.
+func validateKey(key string) error {
+	fields := strings.Fields(key)
+	if len(fields) == 0 {
+		return errors.New("key is empty")
+	}
+	for _, prefix := range keyAlgorithmPrefixes {
+		if strings.HasPrefix(fields[0], prefix) {
+			if len(fields) < 2 {
+				return fmt.Errorf("key of type %q has no key data", fields[0])
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("key type %q is not one of ssh-rsa, ssh-ed25519, ecdsa-sha2-*, sk-ecdsa-sha2-* or sk-ssh-ed25519*", fields[0])
+}
+
+// CreateKey adds a public key for the authenticated user. The key is first
+// checked to use a known algorithm, so that malformed keys fail without a
+// request; see CreateKeyUnvalidated to skip the check.
 //
 // GitHub API docs: https://docs.github.com/en/rest/users/keys#create-a-public-ssh-key-for-the-authenticated-user
 func (s *UsersService) CreateKey(ctx context.Context, key *Key) (*Key, *Response, error) {
+	if err := validateKey(key.GetKey()); err != nil {
+		return nil, nil, err
+	}
+	return s.CreateKeyUnvalidated(ctx, key)
+}
+
+// CreateKeyUnvalidated adds a public key for the authenticated user like
+// CreateKey, but without checking its algorithm first, for algorithms newer
+// than this library.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/keys#create-a-public-ssh-key-for-the-authenticated-user
+func (s *UsersService) CreateKeyUnvalidated(ctx context.Context, key *Key) (*Key, *Response, error) {
 	u := "user/keys"
 
 	req, err := s.client.NewRequest("POST", u, key)
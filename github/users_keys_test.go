@@ -121,7 +121,7 @@ func TestUsersService_CreateKey(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
 
-	input := &Key{Key: String("k"), Title: String("t")}
+	input := &Key{Key: String("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA"), Title: String("t")}
 
 	mux.HandleFunc("/user/keys", func(w http.ResponseWriter, r *http.Request) {
 		v := new(Key)
@@ -156,6 +156,59 @@ func TestUsersService_CreateKey(t *testing.T) {
 	})
 }
 
+func TestUsersService_CreateKey_invalid(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/user/keys", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	ctx := context.Background()
+	for _, key := range []*Key{
+		{},
+		{Key: String("")},
+		{Key: String("AAAAC3NzaC1lZDI1NTE5AAAAIA")},
+		{Key: String("ssh-ed25519")},
+		{Key: String("ssh-dss AAAAB3NzaC1kc3MAAACBAP")},
+		{Key: String("ssh_rsa AAAAB3NzaC1yc2EAAAADAQAB")},
+	} {
+		if _, _, err := client.Users.CreateKey(ctx, key); err == nil {
+			t.Errorf("Users.CreateKey(%v) returned nil error, want error", key)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("Users.CreateKey made %v requests for invalid keys, want 0", calls)
+	}
+
+	// Validation can be skipped for algorithms unknown to this library.
+	key := &Key{Key: String("ssh-future AAAA")}
+	if _, _, err := client.Users.CreateKeyUnvalidated(ctx, key); err != nil {
+		t.Errorf("Users.CreateKeyUnvalidated returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Users.CreateKeyUnvalidated made %v requests, want 1", calls)
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{
+		"ssh-rsa AAAAB3NzaC1yc2EAAAADAQAB",
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA user@host",
+		"ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTY",
+		"ecdsa-sha2-nistp521 AAAAE2VjZHNhLXNoYTItbmlzdHA1MjE",
+		"sk-ecdsa-sha2-nistp256@openssh.com AAAAInNrLWVjZHNh",
+		"sk-ssh-ed25519@openssh.com AAAAGnNrLXNzaC1lZDI1NTE5",
+		"  ssh-rsa   AAAAB3NzaC1yc2EAAAADAQAB  \n",
+	} {
+		if err := validateKey(key); err != nil {
+			t.Errorf("validateKey(%q) returned error: %v", key, err)
+		}
+	}
+}
+
 func TestUsersService_DeleteKey(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
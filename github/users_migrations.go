@@ -0,0 +1,47 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DownloadMigrationArchive downloads the archive of a migration of the
+// authenticated user and streams it into w, without buffering it in memory.
+// GitHub redirects to a short-lived signed URL for the archive; it is fetched
+// with the client's http.Client but without its credentials, which the
+// storage host would reject.
+//
+// GitHub API docs: https://docs.github.com/en/rest/migrations/users#download-a-user-migration-archive
+func (s *UsersService) DownloadMigrationArchive(ctx context.Context, id int64, w io.Writer) (*Response, error) {
+	u := fmt.Sprintf("user/migrations/%v/archive", id)
+	resp, err := s.client.roundTripWithOptionalFollowRedirect(ctx, u, false, WithMediaType(mediaTypeMigrationsPreview))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusFound, http.StatusMovedPermanently, http.StatusSeeOther, http.StatusTemporaryRedirect:
+		_ = resp.Body.Close()
+		loc := resp.Header.Get("Location")
+		resp, err = s.client.getURL(ctx, loc, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	response := newResponse(resp)
+	if err := CheckResponse(resp); err != nil {
+		return response, err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return response, err
+}
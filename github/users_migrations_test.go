@@ -0,0 +1,111 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUsersService_DownloadMigrationArchive(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	archive := bytes.Repeat([]byte("0123456789"), 1000)
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "")
+		testFormValues(t, r, values{"signature": "s"})
+		w.Header().Set("Content-Type", "application/x-gzip")
+		assertWrite(t, w, archive)
+	}))
+	defer storage.Close()
+
+	mux.HandleFunc("/user/migrations/1/archive", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeMigrationsPreview)
+		http.Redirect(w, r, storage.URL+"/archive.tar.gz?signature=s", http.StatusFound)
+	})
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	client = client.WithAuthToken("token")
+	// The archive is fetched through the client's own transport.
+	var storageRequests int
+	transport := client.client.Transport
+	client.client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host != client.BaseURL.Host {
+			storageRequests++
+		}
+		return transport.RoundTrip(r)
+	})
+	resp, err := client.Users.DownloadMigrationArchive(ctx, 1, &buf)
+	if err != nil {
+		t.Fatalf("Users.DownloadMigrationArchive returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Users.DownloadMigrationArchive returned status %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	if !bytes.Equal(buf.Bytes(), archive) {
+		t.Errorf("Users.DownloadMigrationArchive wrote %v bytes, want the %v bytes of the archive", buf.Len(), len(archive))
+	}
+	if storageRequests != 1 {
+		t.Errorf("Users.DownloadMigrationArchive made %v storage requests through the client transport, want 1", storageRequests)
+	}
+}
+
+func TestUsersService_DownloadMigrationArchive_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/migrations/1/archive", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	resp, err := client.Users.DownloadMigrationArchive(ctx, 1, &buf)
+	if err == nil {
+		t.Fatal("Users.DownloadMigrationArchive returned nil error, want error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Users.DownloadMigrationArchive returned response %+v, want status %v", resp, http.StatusNotFound)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Users.DownloadMigrationArchive wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestUsersService_DownloadMigrationArchive_gzipError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/migrations/1/archive", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept-Encoding", "gzip")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNotFound)
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, `{"message":"Not Found"}`)
+		zw.Close()
+	})
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	_, err := client.Users.DownloadMigrationArchive(ctx, 1, &buf)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Users.DownloadMigrationArchive returned error %v, want an *ErrorResponse", err)
+	}
+	if got, want := errResp.Message, "Not Found"; got != want {
+		t.Errorf("Users.DownloadMigrationArchive error message is %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+)
+
+// ListOrgMembers lists the members of an organization. It behaves exactly like
+// OrganizationsService.ListMembers, and is provided on UsersService for
+// user-centric tooling, such as auditing which members are site admins.
+//
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#list-organization-members
+// GitHub API docs: https://docs.github.com/en/rest/orgs/members#list-public-organization-members
+func (s *UsersService) ListOrgMembers(ctx context.Context, org string, opts *ListMembersOptions) ([]*User, *Response, error) {
+	return (*OrganizationsService)(s).ListMembers(ctx, org, opts)
+}
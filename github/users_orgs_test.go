@@ -0,0 +1,60 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsersService_ListOrgMembers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter": "2fa_disabled",
+			"role":   "admin",
+			"page":   "2",
+		})
+		fmt.Fprint(w, `[{"id":1,"site_admin":true}]`)
+	})
+
+	opt := &ListMembersOptions{
+		Filter:      "2fa_disabled",
+		Role:        "admin",
+		ListOptions: ListOptions{Page: 2},
+	}
+	ctx := context.Background()
+	members, _, err := client.Users.ListOrgMembers(ctx, "o", opt)
+	if err != nil {
+		t.Errorf("Users.ListOrgMembers returned error: %v", err)
+	}
+
+	want := []*User{{ID: Int64(1), SiteAdmin: Bool(true)}}
+	if !cmp.Equal(members, want, cmpUsers) {
+		t.Errorf("Users.ListOrgMembers returned %+v, want %+v", members, want)
+	}
+
+	const methodName = "ListOrgMembers"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.ListOrgMembers(ctx, "\n", opt)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.ListOrgMembers(ctx, "o", opt)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
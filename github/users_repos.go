@@ -0,0 +1,41 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+)
+
+// UserListStarredOptions specifies the optional parameters to the
+// UsersService.ListStarred method. Possible values for Sort are: created,
+// updated. The default is "created".
+type UserListStarredOptions struct {
+	SortOptions
+	ListOptions
+}
+
+// ListStarred lists the repositories starred by a user, along with the time
+// each was starred. Passing the empty string will list the starred
+// repositories for the authenticated user. Unlike
+// ActivityService.ListStarred, it rejects an invalid opts.Direction before
+// making a request.
+//
+// GitHub API docs: https://docs.github.com/en/rest/activity/starring#list-repositories-starred-by-the-authenticated-user
+// GitHub API docs: https://docs.github.com/en/rest/activity/starring#list-repositories-starred-by-a-user
+func (s *UsersService) ListStarred(ctx context.Context, user string, opts *UserListStarredOptions) ([]*StarredRepository, *Response, error) {
+	var activityOpts *ActivityListStarredOptions
+	if opts != nil {
+		if err := opts.SortOptions.validate(); err != nil {
+			return nil, nil, err
+		}
+		activityOpts = &ActivityListStarredOptions{
+			Sort:        opts.Sort,
+			Direction:   opts.Direction,
+			ListOptions: opts.ListOptions,
+		}
+	}
+	return (*ActivityService)(s).ListStarred(ctx, user, activityOpts)
+}
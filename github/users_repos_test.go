@@ -0,0 +1,80 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsersService_ListStarred(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/starred", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", strings.Join([]string{mediaTypeStarringPreview, mediaTypeTopicsPreview}, ", "))
+		testFormValues(t, r, values{
+			"sort":      "created",
+			"direction": "desc",
+			"page":      "2",
+		})
+		fmt.Fprint(w, `[{"starred_at":"2002-02-10T15:30:00Z","repo":{"id":2}}]`)
+	})
+
+	opt := &UserListStarredOptions{SortOptions: SortOptions{Sort: "created", Direction: "desc"}, ListOptions: ListOptions{Page: 2}}
+	ctx := context.Background()
+	repos, _, err := client.Users.ListStarred(ctx, "u", opt)
+	if err != nil {
+		t.Errorf("Users.ListStarred returned error: %v", err)
+	}
+
+	want := []*StarredRepository{{
+		StarredAt:  &Timestamp{time.Date(2002, time.February, 10, 15, 30, 0, 0, time.UTC)},
+		Repository: &Repository{ID: Int64(2)},
+	}}
+	if !cmp.Equal(repos, want) {
+		t.Errorf("Users.ListStarred returned %+v, want %+v", repos, want)
+	}
+
+	const methodName = "ListStarred"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.ListStarred(ctx, "\n", opt)
+		return err
+	})
+
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.ListStarred(ctx, "u", opt)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestUsersService_ListStarred_invalidDirection(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/starred", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Users.ListStarred made a request with an invalid direction")
+	})
+
+	opt := &UserListStarredOptions{SortOptions: SortOptions{Sort: "created", Direction: "descending"}}
+	repos, resp, err := client.Users.ListStarred(context.Background(), "u", opt)
+	if err == nil {
+		t.Error("Users.ListStarred returned no error, want one")
+	}
+	if repos != nil || resp != nil {
+		t.Errorf("Users.ListStarred returned %+v, %+v; want nils", repos, resp)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// SocialAccount represents a social account linked to a user's profile.
+type SocialAccount struct {
+	Provider *string `json:"provider,omitempty"`
+	URL      *string `json:"url,omitempty"`
+}
+
+// socialAccountsRequest is the body of requests adding or deleting
+// social accounts.
+type socialAccountsRequest struct {
+	AccountURLs []string `json:"account_urls"`
+}
+
+// ListSocialAccounts lists the social accounts of a user. Passing the empty
+// string will fetch the social accounts of the authenticated user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/social-accounts#list-social-accounts-for-a-user
+// GitHub API docs: https://docs.github.com/en/rest/users/social-accounts#list-social-accounts-for-the-authenticated-user
+func (s *UsersService) ListSocialAccounts(ctx context.Context, user string, opts *ListOptions) ([]*SocialAccount, *Response, error) {
+	var u string
+	if user != "" {
+		u = fmt.Sprintf("users/%v/social_accounts", user)
+	} else {
+		u = "user/social_accounts"
+	}
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var accounts []*SocialAccount
+	resp, err := s.client.Do(ctx, req, &accounts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return accounts, resp, nil
+}
+
+// AddSocialAccounts adds social accounts to the profile of the authenticated user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/social-accounts#add-social-accounts-for-the-authenticated-user
+func (s *UsersService) AddSocialAccounts(ctx context.Context, accountURLs []string) ([]*SocialAccount, *Response, error) {
+	u := "user/social_accounts"
+	req, err := s.client.NewRequest("POST", u, &socialAccountsRequest{AccountURLs: accountURLs})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var accounts []*SocialAccount
+	resp, err := s.client.Do(ctx, req, &accounts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return accounts, resp, nil
+}
+
+// DeleteSocialAccounts deletes social accounts from the profile of the authenticated user.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/social-accounts#delete-social-accounts-for-the-authenticated-user
+func (s *UsersService) DeleteSocialAccounts(ctx context.Context, accountURLs []string) (*Response, error) {
+	u := "user/social_accounts"
+	req, err := s.client.NewRequest("DELETE", u, &socialAccountsRequest{AccountURLs: accountURLs})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsersService_ListSocialAccounts_authenticatedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/social_accounts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2"})
+		fmt.Fprint(w, `[{"provider":"twitter","url":"https://twitter.com/u"}]`)
+	})
+
+	opt := &ListOptions{Page: 2}
+	ctx := context.Background()
+	accounts, _, err := client.Users.ListSocialAccounts(ctx, "", opt)
+	if err != nil {
+		t.Errorf("Users.ListSocialAccounts returned error: %v", err)
+	}
+
+	want := []*SocialAccount{{Provider: String("twitter"), URL: String("https://twitter.com/u")}}
+	if !cmp.Equal(accounts, want) {
+		t.Errorf("Users.ListSocialAccounts returned %+v, want %+v", accounts, want)
+	}
+
+	const methodName = "ListSocialAccounts"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.ListSocialAccounts(ctx, "", opt)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestUsersService_ListSocialAccounts_specifiedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/social_accounts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"provider":"linkedin","url":"https://www.linkedin.com/in/u"}]`)
+	})
+
+	ctx := context.Background()
+	accounts, _, err := client.Users.ListSocialAccounts(ctx, "u", nil)
+	if err != nil {
+		t.Errorf("Users.ListSocialAccounts returned error: %v", err)
+	}
+
+	want := []*SocialAccount{{Provider: String("linkedin"), URL: String("https://www.linkedin.com/in/u")}}
+	if !cmp.Equal(accounts, want) {
+		t.Errorf("Users.ListSocialAccounts returned %+v, want %+v", accounts, want)
+	}
+}
+
+func TestUsersService_ListSocialAccounts_invalidUser(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	_, _, err := client.Users.ListSocialAccounts(ctx, "%", nil)
+	testURLParseError(t, err)
+}
+
+func TestUsersService_AddSocialAccounts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := []string{"https://twitter.com/u", "https://facebook.com/u"}
+
+	mux.HandleFunc("/user/social_accounts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"account_urls":["https://twitter.com/u","https://facebook.com/u"]}`+"\n")
+		fmt.Fprint(w, `[{"provider":"twitter","url":"https://twitter.com/u"},{"provider":"facebook","url":"https://facebook.com/u"}]`)
+	})
+
+	ctx := context.Background()
+	accounts, _, err := client.Users.AddSocialAccounts(ctx, input)
+	if err != nil {
+		t.Errorf("Users.AddSocialAccounts returned error: %v", err)
+	}
+
+	want := []*SocialAccount{
+		{Provider: String("twitter"), URL: String("https://twitter.com/u")},
+		{Provider: String("facebook"), URL: String("https://facebook.com/u")},
+	}
+	if !cmp.Equal(accounts, want) {
+		t.Errorf("Users.AddSocialAccounts returned %+v, want %+v", accounts, want)
+	}
+
+	const methodName = "AddSocialAccounts"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.Users.AddSocialAccounts(ctx, input)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
+
+func TestUsersService_DeleteSocialAccounts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := []string{"https://twitter.com/u"}
+
+	mux.HandleFunc("/user/social_accounts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testHeader(t, r, "Content-Type", "application/json")
+		testBody(t, r, `{"account_urls":["https://twitter.com/u"]}`+"\n")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	_, err := client.Users.DeleteSocialAccounts(ctx, input)
+	if err != nil {
+		t.Errorf("Users.DeleteSocialAccounts returned error: %v", err)
+	}
+
+	const methodName = "DeleteSocialAccounts"
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		return client.Users.DeleteSocialAccounts(ctx, input)
+	})
+}
+
+func TestSocialAccount_Marshal(t *testing.T) {
+	testJSONMarshal(t, &SocialAccount{}, "{}")
+
+	u := &SocialAccount{
+		Provider: String("twitter"),
+		URL:      String("https://twitter.com/u"),
+	}
+
+	want := `{
+		"provider": "twitter",
+		"url": "https://twitter.com/u"
+	}`
+
+	testJSONMarshal(t, u, want)
+}
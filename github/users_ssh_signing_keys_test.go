@@ -146,9 +146,9 @@ func TestUsersService_CreateSSHSigningKey(t *testing.T) {
 		t.Errorf("Users.CreateSSHSigningKey returned %+v, want %+v", key, want)
 	}
 
-	const methodName = "CreateKey"
+	const methodName = "CreateSSHSigningKey"
 	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
-		got, resp, err := client.Users.CreateKey(ctx, input)
+		got, resp, err := client.Users.CreateSSHSigningKey(ctx, input)
 		if got != nil {
 			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
 		}
@@ -6,10 +6,17 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -141,6 +148,234 @@ func TestUser_Marshal(t *testing.T) {
 		"ldap_dn": "test ldap"
 	}`
 	testJSONMarshal(t, u2, want2)
+	assertRoundTrip(t, u2)
+}
+
+func TestUser_Raw(t *testing.T) {
+	var u *User
+	if got := u.Raw(); got != nil {
+		t.Errorf("nil User.Raw() = %s, want nil", got)
+	}
+
+	// Unknown fields are not collected by default.
+	data := `{"login":"l","id":1,"user_view_type":"public","pronouns":{"text":"they/them"}}`
+	u = new(User)
+	if err := json.Unmarshal([]byte(data), u); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if got := u.Raw(); got != nil {
+		t.Errorf("User.Raw() = %s, want nil without WithRawJSON", got)
+	}
+
+	// Structs embedding User decode their own fields too.
+	var embedding struct {
+		User
+		Extra string `json:"user_view_type"`
+	}
+	if err := json.Unmarshal([]byte(data), &embedding); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if embedding.GetLogin() != "l" || embedding.Extra != "public" {
+		t.Errorf("json.Unmarshal into a struct embedding User returned %+v", embedding)
+	}
+}
+
+func TestUsersService_Get_withRawJSON(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/l", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"l","id":1,"user_view_type":"public","pronouns":{"text":"they/them"}}`)
+	})
+	mux.HandleFunc("/users/known", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"known","id":2}`)
+	})
+
+	ctx := context.Background()
+	u, _, err := client.Users.Get(ctx, "l")
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if got := u.Raw(); got != nil {
+		t.Errorf("User.Raw() = %s, want nil without WithRawJSON", got)
+	}
+
+	u, _, err = client.Users.Get(ctx, "l", WithRawJSON())
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	want := &User{
+		Login:   String("l"),
+		ID:      Int64(1),
+		rawJSON: json.RawMessage(`{"pronouns":{"text":"they/them"},"user_view_type":"public"}`),
+	}
+	if !cmp.Equal(u, want, cmpUsers) {
+		t.Errorf("Users.Get returned %+v, want %+v", u, want)
+	}
+	var unknown struct {
+		UserViewType string `json:"user_view_type"`
+	}
+	if err := json.Unmarshal(u.Raw(), &unknown); err != nil {
+		t.Fatalf("json.Unmarshal of User.Raw() returned error: %v", err)
+	}
+	if got, want := unknown.UserViewType, "public"; got != want {
+		t.Errorf("user_view_type = %q, want %q", got, want)
+	}
+
+	u, _, err = client.Users.Get(ctx, "known", WithRawJSON())
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if got := u.Raw(); got != nil {
+		t.Errorf("User.Raw() = %s, want nil when all fields are known", got)
+	}
+}
+
+func TestDecodeWithRawJSON_list(t *testing.T) {
+	var users []*User
+	data := `[{"id":1,"a":1},{"id":2}]`
+	if err := decodeWithRawJSON(strings.NewReader(data), &users); err != nil {
+		t.Fatalf("decodeWithRawJSON returned error: %v", err)
+	}
+	want := []*User{{ID: Int64(1), rawJSON: json.RawMessage(`{"a":1}`)}, {ID: Int64(2)}}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("decodeWithRawJSON returned %+v, want %+v", users, want)
+	}
+}
+
+func TestUser_Raw_largeNumbers(t *testing.T) {
+	// 2^53 + 1 is the smallest integer a float64 cannot hold.
+	u := new(User)
+	if err := decodeWithRawJSON(strings.NewReader(`{"id":9007199254740993,"enterprise_id":9007199254740993}`), u); err != nil {
+		t.Fatalf("decodeWithRawJSON returned error: %v", err)
+	}
+	if got, want := u.GetID(), int64(9007199254740993); got != want {
+		t.Errorf("User.ID = %v, want %v", got, want)
+	}
+	if got, want := string(u.Raw()), `{"enterprise_id":9007199254740993}`; got != want {
+		t.Errorf("User.Raw() = %s, want %s", got, want)
+	}
+
+	var fields map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(u.Raw()))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		t.Fatalf("decoding User.Raw() returned error: %v", err)
+	}
+	id, err := ParseID(fields["enterprise_id"])
+	if err != nil || id != 9007199254740993 {
+		t.Errorf("ParseID(enterprise_id) = %v, %v; want 9007199254740993", id, err)
+	}
+}
+
+func TestUser_HasPaidPlan(t *testing.T) {
+	for _, test := range []struct {
+		user *User
+		want bool
+	}{
+		{nil, false},
+		{&User{}, false},
+		{&User{Plan: &Plan{}}, false},
+		{&User{Plan: &Plan{Name: String("free")}}, false},
+		{&User{Plan: &Plan{Name: String("pro")}}, true},
+	} {
+		if got := test.user.HasPaidPlan(); got != test.want {
+			t.Errorf("%v.HasPaidPlan() = %v, want %v", test.user, got, test.want)
+		}
+	}
+}
+
+func TestUser_IsSpammy(t *testing.T) {
+	for _, test := range []struct {
+		user *User
+		want bool
+	}{
+		{nil, false},
+		{&User{}, false},
+		{&User{Spammy: Bool(false)}, false},
+		{&User{Spammy: Bool(true)}, true},
+	} {
+		if got := test.user.IsSpammy(); got != test.want {
+			t.Errorf("%v.IsSpammy() = %v, want %v", test.user, got, test.want)
+		}
+	}
+}
+
+func TestUser_IsSuspended(t *testing.T) {
+	for _, test := range []struct {
+		user *User
+		want bool
+	}{
+		{nil, false},
+		{&User{}, false},
+		{&User{SuspendedAt: &Timestamp{referenceTime}}, true},
+	} {
+		if got := test.user.IsSuspended(); got != test.want {
+			t.Errorf("%v.IsSuspended() = %v, want %v", test.user, got, test.want)
+		}
+	}
+}
+
+func TestUsersService_GetByID_suspended(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"suspended_at":`+referenceTimeStr+`}`)
+	})
+	mux.HandleFunc("/user/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2}`)
+	})
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		id   int64
+		want *User
+	}{
+		{1, &User{ID: Int64(1), SuspendedAt: &Timestamp{referenceTime}}},
+		{2, &User{ID: Int64(2)}},
+	} {
+		user, _, err := client.Users.GetByID(ctx, test.id)
+		if err != nil {
+			t.Fatalf("Users.GetByID returned error: %v", err)
+		}
+		if !cmp.Equal(user, test.want, cmpUsers) {
+			t.Errorf("Users.GetByID returned %+v, want %+v", user, test.want)
+		}
+		if got, want := user.IsSuspended(), test.want.SuspendedAt != nil; got != want {
+			t.Errorf("Users.GetByID(%v).IsSuspended() = %v, want %v", test.id, got, want)
+		}
+	}
+}
+
+func TestUsersService_ListAll_spammy(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"login":"a","id":1,"site_admin":false,"spammy":true},
+			{"login":"b","id":2,"site_admin":true,"spammy":false}
+		]`)
+	})
+
+	ctx := context.Background()
+	users, _, err := client.Users.ListAll(ctx, nil)
+	if err != nil {
+		t.Errorf("Users.ListAll returned error: %v", err)
+	}
+
+	want := []*User{
+		{Login: String("a"), ID: Int64(1), SiteAdmin: Bool(false), Spammy: Bool(true)},
+		{Login: String("b"), ID: Int64(2), SiteAdmin: Bool(true), Spammy: Bool(false)},
+	}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.ListAll returned %+v, want %+v", users, want)
+	}
+	if !users[0].IsSpammy() || users[1].IsSpammy() {
+		t.Errorf("Users.ListAll returned unexpected spammy flags: %+v", users)
+	}
 }
 
 func TestUsersService_Get_authenticatedUser(t *testing.T) {
@@ -159,7 +394,7 @@ func TestUsersService_Get_authenticatedUser(t *testing.T) {
 	}
 
 	want := &User{ID: Int64(1)}
-	if !cmp.Equal(user, want) {
+	if !cmp.Equal(user, want, cmpUsers) {
 		t.Errorf("Users.Get returned %+v, want %+v", user, want)
 	}
 
@@ -194,11 +429,124 @@ func TestUsersService_Get_specifiedUser(t *testing.T) {
 	}
 
 	want := &User{ID: Int64(1)}
-	if !cmp.Equal(user, want) {
+	if !cmp.Equal(user, want, cmpUsers) {
 		t.Errorf("Users.Get returned %+v, want %+v", user, want)
 	}
 }
 
+func TestUsersService_GetMany(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		switch login := strings.TrimPrefix(r.URL.Path, "/users/"); login {
+		case "missing":
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+		default:
+			fmt.Fprintf(w, `{"login":%q}`, login)
+		}
+	})
+
+	ctx := context.Background()
+	logins := []string{"a", "b", "missing", "c", "a", "d"}
+	users, err := client.Users.GetMany(ctx, logins, 2)
+
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("Users.GetMany returned error %v, want *MultiError", err)
+	}
+	if len(multiErr.Errors) != 1 {
+		t.Errorf("MultiError.Errors = %v, want only the missing login", multiErr.Errors)
+	}
+	var errResp *ErrorResponse
+	if !errors.As(multiErr.Errors["missing"], &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("MultiError.Errors[missing] = %v, want a 404 *ErrorResponse", multiErr.Errors["missing"])
+	}
+	if !errors.As(err, &errResp) {
+		t.Errorf("errors.As(%v, *ErrorResponse) = false, want true", err)
+	}
+
+	want := map[string]*User{
+		"a": {Login: String("a")},
+		"b": {Login: String("b")},
+		"c": {Login: String("c")},
+		"d": {Login: String("d")},
+	}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.GetMany returned %+v, want %+v", users, want)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Users.GetMany made %v concurrent requests, want at most 2", maxInFlight)
+	}
+}
+
+func TestUsersService_GetMany_canceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cancel()
+		fmt.Fprint(w, `{"login":"a"}`)
+	})
+
+	users, err := client.Users.GetMany(ctx, []string{"a", "b", "c", "d"}, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Users.GetMany returned error %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("Users.GetMany made %v requests after cancellation, want 1", calls)
+	}
+	if len(users) > 1 {
+		t.Errorf("Users.GetMany returned %v users, want at most 1", len(users))
+	}
+}
+
+func TestUsersService_Get_ifModifiedSince(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	since := time.Date(2023, time.March, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "If-Modified-Since", "Wed, 01 Mar 2023 11:30:00 GMT")
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	ctx := context.Background()
+	user, resp, err := client.Users.Get(ctx, "u", WithIfModifiedSince(since))
+	if user != nil {
+		t.Errorf("Users.Get returned %+v, want nil", user)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("Users.Get returned response %+v, want status %v", resp, http.StatusNotModified)
+	}
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotModified {
+		t.Errorf("Users.Get returned error %v, want a 304 *ErrorResponse", err)
+	}
+}
+
 func TestUsersService_Get_invalidUser(t *testing.T) {
 	client, _, _, teardown := setup()
 	defer teardown()
@@ -224,7 +572,7 @@ func TestUsersService_GetByID(t *testing.T) {
 	}
 
 	want := &User{ID: Int64(1)}
-	if !cmp.Equal(user, want) {
+	if !cmp.Equal(user, want, cmpUsers) {
 		t.Errorf("Users.GetByID returned %+v, want %+v", user, want)
 	}
 
@@ -243,6 +591,145 @@ func TestUsersService_GetByID(t *testing.T) {
 	})
 }
 
+func TestUsersService_GetByNodeID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+	mux.HandleFunc("/user/583231", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":583231}`)
+	})
+
+	ctx := context.Background()
+	for nodeID, id := range map[string]int64{
+		"MDQ6VXNlcjE=": 1,
+		"U_kgDOAAjmPw": 583231,
+	} {
+		user, _, err := client.Users.GetByNodeID(ctx, nodeID)
+		if err != nil {
+			t.Fatalf("Users.GetByNodeID(%q) returned error: %v", nodeID, err)
+		}
+
+		want := &User{ID: Int64(id)}
+		if !cmp.Equal(user, want, cmpUsers) {
+			t.Errorf("Users.GetByNodeID(%q) returned %+v, want %+v", nodeID, user, want)
+		}
+	}
+}
+
+func TestUsersService_GetByNodeID_invalid(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	for _, nodeID := range []string{
+		"",
+		"bogus",
+		"MDQ6VXNlcg==", // "04:User"
+		"MDM6Qm90MQ==", // "03:Bot1"
+		"U_kgA",        // [0] without an ID
+		"U_not-msgpack!!",
+	} {
+		user, resp, err := client.Users.GetByNodeID(ctx, nodeID)
+		if err == nil {
+			t.Errorf("Users.GetByNodeID(%q) returned nil error, want error", nodeID)
+		}
+		if user != nil || resp != nil {
+			t.Errorf("Users.GetByNodeID(%q) returned %+v, %+v, want nil", nodeID, user, resp)
+		}
+	}
+}
+
+func TestUsersService_GetAvatar(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"login":"u","avatar_url":%q}`, serverURL+baseURLPath+"/avatars/u")
+	})
+	mux.HandleFunc("/avatars/u", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, baseURLPath+"/cdn/u.png", http.StatusFound)
+	})
+	mux.HandleFunc("/cdn/u.png", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "image/*")
+		w.Header().Set("Content-Type", "image/png")
+		assertWrite(t, w, png)
+	})
+
+	ctx := context.Background()
+	avatar, _, err := client.Users.GetAvatar(ctx, "u")
+	if err != nil {
+		t.Fatalf("Users.GetAvatar returned error: %v", err)
+	}
+	if !cmp.Equal(avatar, png) {
+		t.Errorf("Users.GetAvatar returned %v, want %v", avatar, png)
+	}
+
+	const methodName = "GetAvatar"
+	testBadOptions(t, methodName, func() (err error) {
+		_, _, err = client.Users.GetAvatar(ctx, "\n")
+		return err
+	})
+}
+
+func TestUsersService_GetAvatar_crossHost(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "")
+		if got, want := r.URL.RawQuery, "v=4"; got != want {
+			t.Errorf("avatar request has query %q, want %q", got, want)
+		}
+		assertWrite(t, w, png)
+	}))
+	defer cdn.Close()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Authorization", "Bearer secret")
+		fmt.Fprintf(w, `{"login":"u","avatar_url":%q}`, cdn.URL+"/u/1?v=4")
+	})
+
+	client = client.WithAuthToken("secret")
+	client.DefaultPerPage = 50
+	avatar, _, err := client.Users.GetAvatar(context.Background(), "u")
+	if err != nil {
+		t.Fatalf("Users.GetAvatar returned error: %v", err)
+	}
+	if !cmp.Equal(avatar, png) {
+		t.Errorf("Users.GetAvatar returned %v, want %v", avatar, png)
+	}
+}
+
+func TestUsersService_GetAvatar_noAvatarURL(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"login":"u"}`)
+	})
+
+	ctx := context.Background()
+	avatar, _, err := client.Users.GetAvatar(ctx, "u")
+	if err == nil {
+		t.Fatal("Users.GetAvatar returned nil error, want error")
+	}
+	if avatar != nil {
+		t.Errorf("Users.GetAvatar returned %v, want nil", avatar)
+	}
+}
+
 //	@note: move this to user_test.go
 // func TestUsersService_Edit(t *testing.T) {
 // 	client, mux, _, teardown := setup()
@@ -320,6 +807,64 @@ func TestUsersService_GetHovercard(t *testing.T) {
 	})
 }
 
+func TestUsersService_GetHovercard_invalidOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/u/hovercard", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Users.GetHovercard made a request with invalid options")
+	})
+
+	ctx := context.Background()
+	for _, opts := range []*HovercardOptions{
+		{SubjectType: "repository"},
+		{SubjectID: "20180408"},
+		{SubjectType: "team", SubjectID: "1"},
+	} {
+		hovercard, resp, err := client.Users.GetHovercard(ctx, "u", opts)
+		if err == nil {
+			t.Errorf("Users.GetHovercard(%+v) returned nil error, want error", opts)
+		}
+		if hovercard != nil || resp != nil {
+			t.Errorf("Users.GetHovercard(%+v) returned %+v, %+v, want nil", opts, hovercard, resp)
+		}
+	}
+}
+
+func TestUsersService_GetHovercardFor(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var wantSubjectType string
+	mux.HandleFunc("/users/u/hovercard", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"subject_type": wantSubjectType, "subject_id": "1"})
+		fmt.Fprint(w, `{"contexts": [{"message":"m", "octicon": "o"}]}`)
+	})
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		subjectType string
+		fn          func(ctx context.Context, user, id string) (*Hovercard, *Response, error)
+	}{
+		{"organization", client.Users.GetHovercardForOrg},
+		{"repository", client.Users.GetHovercardForRepo},
+		{"issue", client.Users.GetHovercardForIssue},
+		{"pull_request", client.Users.GetHovercardForPullRequest},
+	} {
+		wantSubjectType = test.subjectType
+		hovercard, _, err := test.fn(ctx, "u", "1")
+		if err != nil {
+			t.Errorf("GetHovercard for %v returned error: %v", test.subjectType, err)
+		}
+
+		want := &Hovercard{Contexts: []*UserContext{{Message: String("m"), Octicon: String("o")}}}
+		if !cmp.Equal(hovercard, want) {
+			t.Errorf("GetHovercard for %v returned %+v, want %+v", test.subjectType, hovercard, want)
+		}
+	}
+}
+
 func TestUsersService_ListAll(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -330,7 +875,7 @@ func TestUsersService_ListAll(t *testing.T) {
 		fmt.Fprint(w, `[{"id":2}]`)
 	})
 
-	opt := &UserListOptions{1, ListOptions{Page: 2}}
+	opt := &UserListOptions{Since: 1, ListOptions: ListOptions{Page: 2}}
 	ctx := context.Background()
 	users, _, err := client.Users.ListAll(ctx, opt)
 	if err != nil {
@@ -338,7 +883,7 @@ func TestUsersService_ListAll(t *testing.T) {
 	}
 
 	want := []*User{{ID: Int64(2)}}
-	if !cmp.Equal(users, want) {
+	if !cmp.Equal(users, want, cmpUsers) {
 		t.Errorf("Users.ListAll returned %+v, want %+v", users, want)
 	}
 
@@ -469,6 +1014,238 @@ func TestUsersService_ListAll(t *testing.T) {
 // 	testJSONMarshal(t, u, want)
 // }
 
+func TestNextSince(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []*User
+		want  int64
+	}{
+		{"nil", nil, 0},
+		{"empty", []*User{}, 0},
+		{"ascending", []*User{{ID: Int64(1)}, {ID: Int64(5)}, {ID: Int64(9)}}, 9},
+		{"unordered", []*User{{ID: Int64(7)}, {ID: Int64(3)}}, 7},
+		{"nil IDs", []*User{{ID: Int64(4)}, {Login: String("u")}, nil}, 4},
+		{"only nil IDs", []*User{{}, nil}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := NextSince(tt.users); got != tt.want {
+			t.Errorf("NextSince(%v) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUsersService_ListAllSince(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var sinces []string
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		since := r.FormValue("since")
+		testFormValues(t, r, values{"since": since, "per_page": "3"})
+		sinces = append(sinces, since)
+		switch since {
+		case "1":
+			fmt.Fprint(w, `[{"id":2},{"id":3},{"id":4}]`)
+		case "4":
+			fmt.Fprint(w, `[{"id":5},{"id":8},{"id":9}]`)
+		default:
+			t.Errorf("unexpected request with since=%v", since)
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	ctx := context.Background()
+	users, err := client.Users.ListAllSince(ctx, 1, 7, &UserListOptions{Since: 100, ListOptions: ListOptions{PerPage: 3}})
+	if err != nil {
+		t.Fatalf("Users.ListAllSince returned error: %v", err)
+	}
+
+	want := []*User{{ID: Int64(2)}, {ID: Int64(3)}, {ID: Int64(4)}, {ID: Int64(5)}}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.ListAllSince returned %+v, want %+v", users, want)
+	}
+	if wantSinces := []string{"1", "4"}; !cmp.Equal(sinces, wantSinces) {
+		t.Errorf("Users.ListAllSince requested since values %v, want %v", sinces, wantSinces)
+	}
+}
+
+func TestUsersService_ListAllSince_exhausted(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("since") == "0" || r.FormValue("since") == "" {
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx := context.Background()
+	users, err := client.Users.ListAllSince(ctx, 0, 10, nil)
+	if err != nil {
+		t.Fatalf("Users.ListAllSince returned error: %v", err)
+	}
+
+	want := []*User{{ID: Int64(1)}, {ID: Int64(2)}}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.ListAllSince returned %+v, want %+v", users, want)
+	}
+
+	const methodName = "ListAllSince"
+	testBadOptions(t, methodName, func() (err error) {
+		client.BaseURL.Path = ""
+		_, err = client.Users.ListAllSince(ctx, 0, 10, nil)
+		return err
+	})
+}
+
+func TestUsersService_ListAll_filterType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"since": "1"})
+		fmt.Fprint(w, `[{"id":2,"type":"User"},{"id":3,"type":"Organization"},{"id":4,"type":"User"},{"id":5,"type":"Organization"}]`)
+	})
+
+	opt := &UserListOptions{Since: 1, Type: "User"}
+	ctx := context.Background()
+	users, _, err := client.Users.ListAll(ctx, opt)
+	if err != nil {
+		t.Errorf("Users.ListAll returned error: %v", err)
+	}
+
+	want := []*User{{ID: Int64(2), Type: String("User")}, {ID: Int64(4), Type: String("User")}}
+	if !cmp.Equal(users, want, cmpUsers) {
+		t.Errorf("Users.ListAll returned %+v, want %+v", users, want)
+	}
+}
+
+func TestUsersService_ListAllChan_filterType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		switch since := r.FormValue("since"); since {
+		case "":
+			fmt.Fprint(w, `[{"id":1,"type":"User"},{"id":2,"type":"Organization"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id":3,"type":"Organization"}]`)
+		case "3":
+			fmt.Fprint(w, `[{"id":4,"type":"User"}]`)
+		case "4":
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected since value %q", since)
+		}
+	})
+
+	ctx := context.Background()
+	users, errs := client.Users.ListAllChan(ctx, &UserListOptions{Type: "User"})
+
+	var got []int64
+	for u := range users {
+		got = append(got, u.GetID())
+	}
+	if err, ok := <-errs; ok {
+		t.Errorf("Users.ListAllChan returned error: %v", err)
+	}
+
+	want := []int64{1, 4}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Users.ListAllChan returned %v, want %v", got, want)
+	}
+}
+
+func TestUsersService_ListAllChan(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch since := r.FormValue("since"); since {
+		case "":
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id":3},{"id":4}]`)
+		case "4":
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected since value %q", since)
+		}
+	})
+
+	ctx := context.Background()
+	users, errs := client.Users.ListAllChan(ctx, nil)
+
+	var got []int64
+	for u := range users {
+		got = append(got, u.GetID())
+	}
+	if err, ok := <-errs; ok {
+		t.Errorf("Users.ListAllChan returned error: %v", err)
+	}
+
+	want := []int64{1, 2, 3, 4}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Users.ListAllChan returned %v, want %v", got, want)
+	}
+}
+
+func TestUsersService_ListAllChan_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("since") == "" {
+			fmt.Fprint(w, `[{"id":1}]`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ctx := context.Background()
+	users, errs := client.Users.ListAllChan(ctx, nil)
+
+	var count int
+	for range users {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Users.ListAllChan sent %v users, want 1", count)
+	}
+	if err := <-errs; err == nil {
+		t.Error("Users.ListAllChan returned nil error, want error")
+	}
+	if _, ok := <-errs; ok {
+		t.Error("Users.ListAllChan error channel was not closed")
+	}
+}
+
+func TestUsersService_ListAllChan_canceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	users, errs := client.Users.ListAllChan(ctx, nil)
+
+	<-users
+	cancel()
+	for range users {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Users.ListAllChan returned error %v, want %v", err, context.Canceled)
+	}
+}
+
 func TestHovercard_Marshal(t *testing.T) {
 	testJSONMarshal(t, &Hovercard{}, "{}")
 
@@ -0,0 +1,45 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import "context"
+
+// WhoAmI returns the authenticated user, fetching it with GET /user on first
+// use and returning the cached result, along with the response it was read
+// from, afterwards. Failed lookups are not cached. It is safe for concurrent
+// use; concurrent first calls share a single request.
+//
+// The cache belongs to c, so clients derived from it with a different token,
+// such as by WithAuthToken, look the user up again. To bypass the cache, use
+// Users.Get with an empty user; to drop it, call ResetWhoAmI. The returned
+// User is shared between callers and must not be modified.
+//
+// GitHub API docs: https://docs.github.com/en/rest/users/users#get-the-authenticated-user
+func (c *Client) WhoAmI(ctx context.Context) (*User, *Response, error) {
+	c.whoAmIMu.Lock()
+	defer c.whoAmIMu.Unlock()
+
+	if c.whoAmI != nil {
+		return c.whoAmI, c.whoAmIResp, nil
+	}
+
+	user, resp, err := c.Users.Get(ctx, "")
+	if err != nil {
+		return nil, resp, err
+	}
+
+	c.whoAmI, c.whoAmIResp = user, resp
+	return user, resp, nil
+}
+
+// ResetWhoAmI drops the user cached by WhoAmI, so that the next call to
+// WhoAmI fetches it again.
+func (c *Client) ResetWhoAmI() {
+	c.whoAmIMu.Lock()
+	defer c.whoAmIMu.Unlock()
+
+	c.whoAmI, c.whoAmIResp = nil, nil
+}
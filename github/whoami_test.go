@@ -0,0 +1,111 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWhoAmI(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int32
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"id":1,"login":%q}`, r.Header.Get("Authorization"))
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			user, resp, err := client.WhoAmI(ctx)
+			if err != nil {
+				t.Errorf("WhoAmI returned error: %v", err)
+				return
+			}
+			if want := (&User{ID: Int64(1), Login: String("")}); !cmp.Equal(user, want, cmpUsers) {
+				t.Errorf("WhoAmI returned %+v, want %+v", user, want)
+			}
+			if resp == nil || resp.StatusCode != http.StatusOK {
+				t.Errorf("WhoAmI returned response %+v, want the cached 200 response", resp)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("WhoAmI sent %v requests, want 1", got)
+	}
+
+	// A client with another token has its own cache.
+	user, _, err := client.WithAuthToken("t").WhoAmI(ctx)
+	if err != nil {
+		t.Fatalf("WhoAmI returned error: %v", err)
+	}
+	if got, want := user.GetLogin(), "Bearer t"; got != want {
+		t.Errorf("WhoAmI with token returned login %q, want %q", got, want)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("WhoAmI sent %v requests, want 2", got)
+	}
+
+	client.ResetWhoAmI()
+	if _, _, err := client.WhoAmI(ctx); err != nil {
+		t.Fatalf("WhoAmI returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("WhoAmI after ResetWhoAmI sent %v requests, want 3", got)
+	}
+}
+
+func TestWhoAmI_errorNotCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	fail := true
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.WhoAmI(ctx); err == nil {
+		t.Fatal("WhoAmI returned nil error, want the server error")
+	}
+
+	fail = false
+	user, _, err := client.WhoAmI(ctx)
+	if err != nil {
+		t.Fatalf("WhoAmI returned error: %v", err)
+	}
+	if got := user.GetID(); got != 1 {
+		t.Errorf("WhoAmI returned ID %v, want 1", got)
+	}
+
+	const methodName = "WhoAmI"
+	client.ResetWhoAmI()
+	testNewRequestAndDoFailure(t, methodName, client, func() (*Response, error) {
+		got, resp, err := client.WhoAmI(ctx)
+		if got != nil {
+			t.Errorf("testNewRequestAndDoFailure %v = %#v, want nil", methodName, got)
+		}
+		return resp, err
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright 2023 The go-github AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build github_debug
+// +build github_debug
+
+// This file enables extra checks that help catch mistakes during development.
+
+package github
+
+// debugOptions makes addOptions check its opts argument as AddOptions does.
+const debugOptions = true